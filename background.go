@@ -0,0 +1,132 @@
+package formatdata
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+const (
+	defaultDarkStyle  = "monokai"
+	defaultLightStyle = "github"
+
+	// backgroundQueryTimeout is a time to wait for the terminal's response of OSC 11 query.
+	backgroundQueryTimeout = 100 * time.Millisecond
+)
+
+// resolveStyle converts "auto" style into actual style name by terminal's background color.
+func resolveStyle(style string, out io.Writer) string {
+	dark, light, ok := parseAutoStyle(style)
+	if !ok {
+		return style
+	}
+	if isLightBackground(out) {
+		return light
+	}
+	return dark
+}
+
+// parseAutoStyle parses "auto", "auto:dark=monokai,light=github" or "dark=monokai,light=github" style.
+func parseAutoStyle(style string) (dark, light string, ok bool) {
+	if style != "auto" && !strings.Contains(style, "=") {
+		return "", "", false
+	}
+	dark = defaultDarkStyle
+	light = defaultLightStyle
+	for _, pair := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(style, "auto"), ":"), ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || value == "" {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "dark":
+			dark = strings.TrimSpace(value)
+		case "light":
+			light = strings.TrimSpace(value)
+		}
+	}
+	return dark, light, true
+}
+
+// isLightBackground asks terminal's background color by OSC 11 if out is terminal.
+// If the terminal doesn't respond, it uses COLORFGBG environment variable. Default is dark.
+func isLightBackground(out io.Writer) bool {
+	if isTerminal(out) {
+		if c, ok := terminalBackground(); ok {
+			return c.Brightness() > 0.5
+		}
+	}
+	if light, ok := parseColorFGBG(os.Getenv("COLORFGBG")); ok {
+		return light
+	}
+	return false
+}
+
+// terminalBackground returns the result of OSC 11 query. The query switches the terminal into raw mode
+// and waits for the response, so it is sent only once per process.
+var terminalBackground = onceBackground(queryBackgroundColor)
+
+func onceBackground(query func(timeout time.Duration) (chroma.Colour, bool)) func() (chroma.Colour, bool) {
+	var once sync.Once
+	var c chroma.Colour
+	var ok bool
+	return func() (chroma.Colour, bool) {
+		once.Do(func() {
+			c, ok = query(backgroundQueryTimeout)
+		})
+		return c, ok
+	}
+}
+
+// da1ResponsePattern matches the response of DA1 request that is sent after OSC 11 query.
+var da1ResponsePattern = regexp.MustCompile("\033\\[\\?[0-9;]*c")
+
+// parseOSC11Response parses terminal's response like "\033]11;rgb:ffff/ffff/ffff\033\\".
+// Following responses like DA1's "\033[?62;22c" are ignored.
+func parseOSC11Response(response string) (chroma.Colour, bool) {
+	_, body, found := strings.Cut(response, "rgb:")
+	if !found {
+		return 0, false
+	}
+	// response may be followed by other responses (e.g. DA1), so the body ends at ST (ESC \) or BEL
+	if end := strings.IndexAny(body, "\a\033"); end >= 0 {
+		body = body[:end]
+	}
+	parts := strings.Split(body, "/")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	var rgb [3]uint8
+	for i, p := range parts {
+		if len(p) == 0 || len(p) > 4 {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil {
+			return 0, false
+		}
+		// scale 1-4 hex digits into 8 bits
+		max := uint64(1)<<(4*len(p)) - 1
+		rgb[i] = uint8(v * 255 / max)
+	}
+	return chroma.NewColour(rgb[0], rgb[1], rgb[2]), true
+}
+
+// parseColorFGBG parses COLORFGBG environment variable like "15;0" or "0;default;15".
+func parseColorFGBG(value string) (light, ok bool) {
+	if value == "" {
+		return false, false
+	}
+	parts := strings.Split(value, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return false, false
+	}
+	return bg == 7 || bg >= 9, true
+}
@@ -0,0 +1,59 @@
+//go:build !windows
+
+package formatdata
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// queryBackgroundColor sends OSC 11 query to the terminal and waits for its response until timeout.
+func queryBackgroundColor(timeout time.Duration) (chroma.Colour, bool) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return 0, false
+	}
+	defer tty.Close()
+	// Don't use tty.Fd() because it makes the file blocking mode and disables read deadline.
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+	var state *terminal.State
+	conn.Control(func(fd uintptr) {
+		state, err = terminal.MakeRaw(int(fd))
+	})
+	if err != nil {
+		return 0, false
+	}
+	defer conn.Control(func(fd uintptr) {
+		terminal.Restore(int(fd), state)
+	})
+
+	// deadline is set before the query. Otherwise late response would leak into shell's input.
+	if err := tty.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, false
+	}
+	// DA1 (primary device attributes) request follows OSC 11. All terminals answer DA1,
+	// so the response of DA1 tells that nothing is left unread, even if the terminal ignores OSC 11.
+	if _, err := io.WriteString(tty, "\033]11;?\033\\\033[c"); err != nil {
+		return 0, false
+	}
+	var response []byte
+	buf := make([]byte, 64)
+	for len(response) < 1024 {
+		n, err := tty.Read(buf)
+		response = append(response, buf[:n]...)
+		if da1ResponsePattern.Match(response) {
+			return parseOSC11Response(string(response))
+		}
+		if err != nil {
+			break
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseAutoStyle(t *testing.T) {
+	tests := []struct {
+		style     string
+		wantDark  string
+		wantLight string
+		wantOk    bool
+	}{
+		{style: "monokai", wantOk: false},
+		{style: "auto", wantDark: "monokai", wantLight: "github", wantOk: true},
+		{style: "dark=dracula,light=friendly", wantDark: "dracula", wantLight: "friendly", wantOk: true},
+		{style: "auto:light=friendly", wantDark: "monokai", wantLight: "friendly", wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			dark, light, ok := parseAutoStyle(tt.style)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantDark, dark)
+			assert.Equal(t, tt.wantLight, light)
+		})
+	}
+}
+
+func Test_parseOSC11Response(t *testing.T) {
+	c, ok := parseOSC11Response("\033]11;rgb:ffff/ffff/ffff\033\\")
+	assert.True(t, ok)
+	assert.Equal(t, chroma.NewColour(255, 255, 255), c)
+
+	c, ok = parseOSC11Response("\033]11;rgb:ffff/ffff/ffff\033\\\033[?62;22c")
+	assert.True(t, ok, "followed by DA1 response")
+	assert.Equal(t, chroma.NewColour(255, 255, 255), c)
+
+	_, ok = parseOSC11Response("\033[?62;22c")
+	assert.False(t, ok, "terminal ignores OSC 11 and answers only DA1")
+
+	assert.True(t, da1ResponsePattern.MatchString("\033]11;rgb:0/0/0\a\033[?1;2c"))
+	assert.False(t, da1ResponsePattern.MatchString("\033]11;rgb:0/0/0\a"))
+
+	c, ok = parseOSC11Response("\033]11;rgb:28/2c/34\a")
+	assert.True(t, ok)
+	assert.Equal(t, chroma.NewColour(0x28, 0x2c, 0x34), c)
+
+	_, ok = parseOSC11Response("\033[?1;2c")
+	assert.False(t, ok)
+}
+
+func Test_resolveStyle(t *testing.T) {
+	t.Setenv("COLORFGBG", "0;15")
+	assert.Equal(t, "github", resolveStyle("auto", &bytes.Buffer{}))
+	t.Setenv("COLORFGBG", "15;default;0")
+	assert.Equal(t, "monokai", resolveStyle("auto", &bytes.Buffer{}))
+	assert.Equal(t, "dracula", resolveStyle("dracula", &bytes.Buffer{}))
+}
+
+func Test_onceBackground(t *testing.T) {
+	count := 0
+	query := onceBackground(func(timeout time.Duration) (chroma.Colour, bool) {
+		count++
+		return chroma.NewColour(255, 255, 255), true
+	})
+	for i := 0; i < 3; i++ {
+		c, ok := query()
+		assert.True(t, ok)
+		assert.Equal(t, chroma.NewColour(255, 255, 255), c)
+	}
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,12 @@
+package formatdata
+
+import (
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// queryBackgroundColor is not supported on Windows. It always fails and COLORFGBG is used instead.
+func queryBackgroundColor(timeout time.Duration) (chroma.Colour, bool) {
+	return 0, false
+}
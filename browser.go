@@ -0,0 +1,489 @@
+package formatdata
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shibukawa/stringwidth"
+)
+
+// interactiveRenderer opens full-screen table browser when out is terminal. Otherwise, it writes Terminal table.
+type interactiveRenderer struct{}
+
+func (interactiveRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	if !isTerminal(out) || len(table) == 0 {
+		// empty table has nothing to browse
+		return terminalRenderer{}.Render(table, out, ctx)
+	}
+	cells, err := prepareTable(table, ctx.Opt)
+	if err != nil {
+		return err
+	}
+	err = runBrowser(newBrowser(cells, ctx), out)
+	if err == errBrowserUnsupported {
+		return terminalRenderer{}.Render(table, out, ctx)
+	}
+	return err
+}
+
+// errBrowserUnsupported is returned by runBrowser on platforms that don't support the browser.
+var errBrowserUnsupported = errors.New("interactive mode is not supported")
+
+const browserHelp = "↑↓←→ move  / search  n/N next/prev  s sort  y copy  q quit"
+
+// browser is a state of interactive table browser. It doesn't touch terminal; runBrowser sends keys to it and draws its view.
+type browser struct {
+	header      []string   // rendered header cells
+	plainHeader []string   // header cells without escape sequence
+	rows        [][]string // rendered body cells
+	plain       [][]string // body cells without escape sequence (for search)
+	values      [][]any    // raw body cells (for sort and copy)
+	widths      []int
+	rightAligns []bool
+	eastAsian   bool
+
+	order     []int // sorted row indexes
+	cursor    int   // index of order
+	top       int   // first visible index of order
+	col       int   // selected column
+	colOffset int   // first visible column except frozen first column
+	sortCol   int
+	sortDesc  bool
+
+	searching bool
+	query     string
+	status    string
+	clipboard string // row JSON to be sent to terminal's clipboard
+
+	width  int
+	height int
+}
+
+func newBrowser(cells [][]any, ctx RenderContext) *browser {
+	tr := ctx.tableRenderer()
+	widths, rightAligns, rendered := calcTableSize(cells, tr, ctx.Opt.EastAsianAmbiguousAsWide)
+	_, _, plain := calcTableSize(cells, plainTextTableRenderer, ctx.Opt.EastAsianAmbiguousAsWide)
+	// rows may be longer than header. Header is padded so that selected column always has header cell.
+	header := padCells(rendered[0], len(widths))
+	plainHeader := padCells(plain[0], len(widths))
+	b := &browser{
+		header:      header,
+		plainHeader: plainHeader,
+		rows:        rendered[1:],
+		plain:       plain[1:],
+		values:      cells[1:],
+		widths:      widths,
+		rightAligns: rightAligns,
+		eastAsian:   ctx.Opt.EastAsianAmbiguousAsWide,
+		colOffset:   1,
+		sortCol:     -1,
+		width:       80,
+		height:      24,
+	}
+	b.order = make([]int, len(b.rows))
+	for i := range b.order {
+		b.order[i] = i
+	}
+	return b
+}
+
+// padCells appends empty cells to make length n.
+func padCells(cells []string, n int) []string {
+	for len(cells) < n {
+		cells = append(cells, "")
+	}
+	return cells
+}
+
+func (b *browser) resize(width, height int) {
+	b.width = width
+	b.height = height
+	b.scroll()
+}
+
+func (b *browser) bodyHeight() int {
+	// header, separator and status line
+	if h := b.height - 3; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// handleKey updates state by the key. It returns true if the browser should quit.
+func (b *browser) handleKey(key string) bool {
+	if b.searching {
+		switch key {
+		case "enter":
+			b.searching = false
+		case "esc", "ctrl-c":
+			b.searching = false
+			b.query = ""
+		case "backspace":
+			if b.query != "" {
+				_, size := utf8.DecodeLastRuneInString(b.query)
+				b.query = b.query[:len(b.query)-size]
+				b.search(0, 1)
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				b.query += key
+				b.search(0, 1)
+			}
+		}
+		return false
+	}
+	b.status = ""
+	switch key {
+	case "q", "esc", "ctrl-c":
+		return true
+	case "up", "k":
+		b.cursor--
+	case "down", "j":
+		b.cursor++
+	case "pgup", "ctrl-b":
+		b.cursor -= b.bodyHeight()
+	case "pgdn", "ctrl-f", " ":
+		b.cursor += b.bodyHeight()
+	case "home", "g":
+		b.cursor = 0
+	case "end", "G":
+		b.cursor = len(b.order) - 1
+	case "left", "h":
+		if b.col > 0 {
+			b.col--
+		}
+	case "right", "l":
+		if b.col < len(b.widths)-1 {
+			b.col++
+		}
+	case "/":
+		b.searching = true
+		b.query = ""
+	case "n":
+		b.search(1, 1)
+	case "N":
+		b.search(-1, -1)
+	case "s":
+		b.sort()
+	case "y", "c":
+		b.copyRow()
+	}
+	b.scroll()
+	return false
+}
+
+// scroll keeps cursor and selected column in the screen.
+func (b *browser) scroll() {
+	if b.cursor >= len(b.order) {
+		b.cursor = len(b.order) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+	if b.cursor < b.top {
+		b.top = b.cursor
+	} else if h := b.bodyHeight(); b.cursor >= b.top+h {
+		b.top = b.cursor - h + 1
+	}
+	if b.col == 0 || len(b.widths) == 0 {
+		return
+	}
+	if b.col < b.colOffset {
+		b.colOffset = b.col
+	}
+	for b.colOffset < b.col {
+		used := 2 + b.widths[0] + 3
+		for i := b.colOffset; i <= b.col; i++ {
+			used += b.widths[i] + 3
+		}
+		if used <= b.width {
+			break
+		}
+		b.colOffset++
+	}
+}
+
+// search moves cursor to the row that contains query. start is offset from cursor and step is direction.
+func (b *browser) search(start, step int) {
+	if b.query == "" || len(b.order) == 0 {
+		return
+	}
+	query := strings.ToLower(b.query)
+	for i := 0; i < len(b.order); i++ {
+		index := ((b.cursor+start+i*step)%len(b.order) + len(b.order)) % len(b.order)
+		for _, c := range b.plain[b.order[index]] {
+			if strings.Contains(strings.ToLower(c), query) {
+				b.cursor = index
+				b.scroll()
+				return
+			}
+		}
+	}
+	b.status = "not found: " + b.query
+}
+
+// sort sorts rows by selected column. Sorting by same column again toggles order.
+func (b *browser) sort() {
+	if b.sortCol == b.col {
+		b.sortDesc = !b.sortDesc
+	} else {
+		b.sortCol = b.col
+		b.sortDesc = false
+	}
+	var current int
+	if len(b.order) > 0 {
+		current = b.order[b.cursor]
+	}
+	sort.SliceStable(b.order, func(i, j int) bool {
+		x, y := b.cell(b.order[i], b.sortCol), b.cell(b.order[j], b.sortCol)
+		if b.sortDesc {
+			return lessCell(y, x)
+		}
+		return lessCell(x, y)
+	})
+	for i, r := range b.order {
+		if r == current {
+			b.cursor = i
+		}
+	}
+}
+
+// cell returns value for sorting. Null and missing cells are their marker text to distinguish them.
+func (b *browser) cell(row, col int) any {
+	if col < len(b.values[row]) {
+		if m, ok := findMarker(b.values[row][col]); ok {
+			return m.text
+		}
+		return unwrapCell(b.values[row][col])
+	}
+	return nil
+}
+
+// findMarker returns markerCell even if it is wrapped by style rules.
+func findMarker(v any) (markerCell, bool) {
+	switch c := v.(type) {
+	case markerCell:
+		return c, true
+	case ruledCell:
+		return findMarker(c.value)
+	case styledCell:
+		return findMarker(c.value)
+	}
+	return markerCell{}, false
+}
+
+// copyRow keeps selected row as JSON object. Row number column and missing cells are not included.
+func (b *browser) copyRow() {
+	if len(b.order) == 0 {
+		return
+	}
+	row := b.order[b.cursor]
+	obj := map[string]any{}
+	var columns []string
+	for c, name := range b.plainHeader {
+		if c >= len(b.values[row]) {
+			// short row is treated as missing cells
+			continue
+		}
+		v := b.values[row][c]
+		if _, ok := v.(rowNumber); ok {
+			continue
+		}
+		if m, ok := findMarker(v); ok && m.missing {
+			// missing key is not written, unlike null
+			continue
+		}
+		obj[name] = unwrapCell(v)
+		columns = append(columns, name)
+	}
+	line, err := encodeJSONLine(obj, columns)
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	b.clipboard = strings.TrimSuffix(line, "\n")
+	b.status = fmt.Sprintf("copied row %d as JSON", b.cursor+1)
+}
+
+// view returns whole screen. Lines end with CRLF because terminal is in raw mode.
+func (b *browser) view() string {
+	var s strings.Builder
+	s.WriteString("\033[H")
+	line := func(text string) {
+		s.WriteString(text)
+		s.WriteString("\033[0m\033[K\r\n")
+	}
+	line(b.line(b.header, "  ", true))
+	var sep strings.Builder
+	sep.WriteString("──")
+	for _, c := range b.visibleColumns() {
+		sep.WriteString(strings.Repeat("─", b.widths[c]+2))
+		if c == 0 {
+			sep.WriteString("╋")
+		} else {
+			sep.WriteString("┼")
+		}
+	}
+	line(sep.String())
+	for i := b.top; i < b.top+b.bodyHeight(); i++ {
+		if i >= len(b.order) {
+			line("")
+			continue
+		}
+		gutter := "  "
+		if i == b.cursor {
+			gutter = "▶ "
+		}
+		line(b.line(b.rows[b.order[i]], gutter, false))
+	}
+	// status line doesn't have new line to avoid scroll
+	s.WriteString("\033[7m")
+	s.WriteString(b.statusLine())
+	s.WriteString("\033[0m\033[K")
+	return s.String()
+}
+
+func (b *browser) visibleColumns() []int {
+	if len(b.widths) == 0 {
+		return nil
+	}
+	result := []int{0}
+	for c := b.colOffset; c < len(b.widths); c++ {
+		result = append(result, c)
+	}
+	return result
+}
+
+func (b *browser) line(cells []string, gutter string, header bool) string {
+	var s strings.Builder
+	s.WriteString(gutter)
+	for _, c := range b.visibleColumns() {
+		var text string
+		if c < len(cells) {
+			text = cells[c]
+		}
+		padding := b.widths[c] - stringwidth.Calc(text, stringwidth.Opt{IsAmbiguousWide: b.eastAsian})
+		if header && c == b.col {
+			text = "\033[7m" + b.plainHeader[c] + "\033[0m"
+		}
+		if b.rightAligns[c] {
+			s.WriteString(strings.Repeat(" ", 1+padding) + text + " ")
+		} else {
+			s.WriteString(" " + text + strings.Repeat(" ", 1+padding))
+		}
+		if c == 0 {
+			s.WriteString("┃")
+		} else {
+			s.WriteString("│")
+		}
+	}
+	return s.String()
+}
+
+func (b *browser) statusLine() string {
+	if b.searching {
+		return "/" + b.query
+	}
+	if b.status != "" {
+		return b.status
+	}
+	position := "row 0/0"
+	if len(b.order) > 0 {
+		position = "row " + strconv.Itoa(b.cursor+1) + "/" + strconv.Itoa(len(b.order))
+	}
+	if b.sortCol >= 0 {
+		mark := "▲"
+		if b.sortDesc {
+			mark = "▼"
+		}
+		position += "  sort: " + b.plainHeader[b.sortCol] + " " + mark
+	}
+	return position + "  " + browserHelp
+}
+
+// lessCell compares cells by number if both are numbers. nil is the smallest.
+func lessCell(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af < bf
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// parseKeys converts input bytes from raw mode terminal into key names like "up", "enter" or a character.
+func parseKeys(input []byte) []string {
+	var keys []string
+	for len(input) > 0 {
+		switch c := input[0]; {
+		case c == 0x1b:
+			if len(input) == 1 || (input[1] != '[' && input[1] != 'O') {
+				keys = append(keys, "esc")
+				input = input[1:]
+				continue
+			}
+			i := 2
+			for i < len(input) && (input[i] < 0x40 || input[i] > 0x7e) {
+				i++
+			}
+			if i >= len(input) {
+				return append(keys, "esc")
+			}
+			switch string(input[2 : i+1]) {
+			case "A":
+				keys = append(keys, "up")
+			case "B":
+				keys = append(keys, "down")
+			case "C":
+				keys = append(keys, "right")
+			case "D":
+				keys = append(keys, "left")
+			case "H", "1~", "7~":
+				keys = append(keys, "home")
+			case "F", "4~", "8~":
+				keys = append(keys, "end")
+			case "5~":
+				keys = append(keys, "pgup")
+			case "6~":
+				keys = append(keys, "pgdn")
+			}
+			input = input[i+1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+			input = input[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+			input = input[1:]
+		case c == 0x03:
+			keys = append(keys, "ctrl-c")
+			input = input[1:]
+		case c == 0x02:
+			keys = append(keys, "ctrl-b")
+			input = input[1:]
+		case c == 0x06:
+			keys = append(keys, "ctrl-f")
+			input = input[1:]
+		case c < 0x20:
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			keys = append(keys, string(r))
+			input = input[size:]
+		}
+	}
+	return keys
+}
+
+// osc52 returns escape sequence that sets text to terminal's clipboard.
+func osc52(text string) string {
+	return "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
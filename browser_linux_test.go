@@ -0,0 +1,48 @@
+package formatdata
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// openPty opens pseudo terminal. It returns master and slave (terminal) side.
+func openPty(t *testing.T) (*os.File, *os.File) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("pseudo terminal is not available: %v", err)
+	}
+	var unlock int32
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		master.Close()
+		t.Skipf("pseudo terminal is not available: %v", errno)
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		master.Close()
+		t.Skipf("pseudo terminal is not available: %v", errno)
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		t.Skipf("pseudo terminal is not available: %v", err)
+	}
+	t.Cleanup(func() {
+		slave.Close()
+		master.Close()
+	})
+	return master, slave
+}
+
+func TestInteractive_EmptyTableOnTerminal(t *testing.T) {
+	_, slave := openPty(t)
+	assert.True(t, isTerminal(slave))
+	assert.NotPanics(t, func() {
+		err := FormatDataWithoutColor([]map[string]any{}, slave, Opt{OutputFormat: Interactive})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+//go:build !windows
+
+package formatdata
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// runBrowser shows b in alternate screen of the terminal and handles keys until it quits.
+func runBrowser(b *browser, out io.Writer) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return errBrowserUnsupported
+	}
+	defer tty.Close()
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return errBrowserUnsupported
+	}
+	var state *terminal.State
+	conn.Control(func(fd uintptr) {
+		state, err = terminal.MakeRaw(int(fd))
+	})
+	if err != nil {
+		return errBrowserUnsupported
+	}
+	defer conn.Control(func(fd uintptr) {
+		terminal.Restore(int(fd), state)
+	})
+
+	// alternate screen, hide cursor and disable line wrap (long lines are clipped)
+	if _, err := io.WriteString(out, "\033[?1049h\033[?25l\033[?7l"); err != nil {
+		return err
+	}
+	defer io.WriteString(out, "\033[?7h\033[?25h\033[?1049l")
+
+	buf := make([]byte, 256)
+	for {
+		conn.Control(func(fd uintptr) {
+			if width, height, err := terminal.GetSize(int(fd)); err == nil && width > 0 && height > 0 {
+				b.resize(width, height)
+			}
+		})
+		if _, err := io.WriteString(out, b.view()); err != nil {
+			return err
+		}
+		n, err := tty.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			if b.handleKey(key) {
+				return nil
+			}
+			if b.clipboard != "" {
+				if _, err := io.WriteString(out, osc52(b.clipboard)); err != nil {
+					return err
+				}
+				b.clipboard = ""
+			}
+		}
+	}
+}
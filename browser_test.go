@@ -0,0 +1,143 @@
+package formatdata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseKeys(t *testing.T) {
+	assert.Equal(t, []string{"up", "down", "right", "left", "pgdn", "home", "enter", "esc", "q", "あ", "backspace"},
+		parseKeys([]byte("\033[A\033[B\033OC\033[D\033[6~\033[H\r\033qあ\x7f")))
+}
+
+func newTestBrowser() *browser {
+	table := [][]any{
+		{"name", "age", "city"},
+		{"alice", 30, "Tokyo"},
+		{"bob", 4, "Osaka"},
+		{"carol", 12, nil},
+	}
+	b := newBrowser(table, RenderContext{Opt: normalizeOpt(nil)})
+	b.resize(80, 5)
+	return b
+}
+
+func TestBrowser_Move(t *testing.T) {
+	b := newTestBrowser()
+	b.handleKey("down")
+	b.handleKey("down")
+	b.handleKey("down")
+	assert.Equal(t, 2, b.cursor, "cursor stops at the last row")
+	assert.Equal(t, 1, b.top, "screen has only two body rows")
+	b.handleKey("home")
+	assert.Equal(t, 0, b.cursor)
+	assert.Equal(t, 0, b.top)
+
+	b.resize(20, 5)
+	b.handleKey("right")
+	b.handleKey("right")
+	assert.Equal(t, 2, b.col)
+	assert.Equal(t, 2, b.colOffset, "age column is scrolled out and name column is frozen")
+	assert.True(t, b.handleKey("q"))
+}
+
+func TestBrowser_Search(t *testing.T) {
+	b := newTestBrowser()
+	for _, k := range parseKeys([]byte("/OSA\r")) {
+		b.handleKey(k)
+	}
+	assert.Equal(t, 1, b.cursor)
+	assert.False(t, b.searching)
+	b.handleKey("n")
+	assert.Equal(t, 1, b.cursor, "only one row matches")
+
+	for _, k := range parseKeys([]byte("/xyz")) {
+		b.handleKey(k)
+	}
+	assert.Equal(t, "not found: xyz", b.status)
+}
+
+func TestBrowser_SortAndCopy(t *testing.T) {
+	b := newTestBrowser()
+	b.handleKey("right")
+	b.handleKey("s")
+	assert.Equal(t, []int{1, 2, 0}, b.order, "sorted by age numerically")
+	assert.Equal(t, 2, b.cursor, "cursor keeps the selected row")
+	b.handleKey("s")
+	assert.Equal(t, []int{0, 2, 1}, b.order, "descending")
+
+	b.handleKey("home")
+	b.handleKey("y")
+	assert.Equal(t, `{"name":"alice","age":30,"city":"Tokyo"}`, b.clipboard)
+	assert.Equal(t, "\033]52;c;eyJhIjoxfQ==\a", osc52(`{"a":1}`))
+
+	b.handleKey("end")
+	b.handleKey("y")
+	assert.Equal(t, `{"name":"bob","age":4,"city":"Osaka"}`, b.clipboard)
+
+	// ragged rows
+	b = newBrowser([][]any{{"a", "b"}, {1}}, RenderContext{Opt: normalizeOpt(nil)})
+	b.resize(80, 5)
+	assert.NotPanics(t, func() {
+		b.handleKey("y")
+	})
+	assert.Equal(t, `{"a":1}`, b.clipboard, "short row is copied without absent cells")
+
+	b = newBrowser([][]any{{"a"}, {1, 2}}, RenderContext{Opt: normalizeOpt(nil)})
+	b.resize(80, 5)
+	assert.NotPanics(t, func() {
+		b.handleKey("right")
+		b.handleKey("s")
+		b.view()
+	})
+	assert.Equal(t, 1, b.col, "column beyond header is selectable")
+	assert.Contains(t, b.view(), "sort:  ▲")
+}
+
+func TestBrowser_View(t *testing.T) {
+	b := newTestBrowser()
+	b.handleKey("down")
+	lines := strings.Split(b.view(), "\r\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "\033[H   \033[7mname\033[0m  ┃ age │ city  │\033[0m\033[K", lines[0])
+	assert.Equal(t, "─────────╋─────┼───────┼\033[0m\033[K", lines[1])
+	assert.Equal(t, "   alice ┃ 30  │ Tokyo │\033[0m\033[K", lines[2])
+	assert.Equal(t, "▶  bob   ┃ 4   │ Osaka │\033[0m\033[K", lines[3])
+	assert.Contains(t, lines[4], "row 2/3")
+}
+
+func TestInteractive_NotTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, FormatDataWithoutColor([]SampleStruct{{A: "x", B: 1}}, &buf, Opt{OutputFormat: Interactive}))
+	assert.Equal(t, trimIndent(`
+		┌───┬───┐
+		│ a │ b │
+		╞═══╪═══╡
+		│ x │ 1 │
+		└───┴───┘
+		`), buf.String())
+}
+
+func TestBrowser_Markers(t *testing.T) {
+	table, err := prepareTable([][]any{
+		{"name", "city"},
+		{"alice", missingCell{}},
+		{"bob", nil},
+		{"carol", "Tokyo"},
+	}, Opt{NullMarker: "null", MissingMarker: "—"})
+	assert.NoError(t, err)
+	b := newBrowser(table, RenderContext{Opt: normalizeOpt(nil)})
+	b.resize(80, 6)
+
+	assert.Equal(t, "—", b.cell(0, 1), "missing and null are distinguished")
+	assert.Equal(t, "null", b.cell(1, 1))
+
+	b.handleKey("y")
+	assert.Equal(t, `{"name":"alice"}`, b.clipboard, "missing key is omitted")
+	b.handleKey("down")
+	b.handleKey("y")
+	assert.Equal(t, `{"name":"bob","city":null}`, b.clipboard, "null is kept")
+}
@@ -0,0 +1,8 @@
+package formatdata
+
+import "io"
+
+// runBrowser is not supported on Windows. Interactive format writes Terminal table instead.
+func runBrowser(b *browser, out io.Writer) error {
+	return errBrowserUnsupported
+}
@@ -0,0 +1,100 @@
+package formatdata
+
+import "fmt"
+
+// splitTableColumns splits table that is wider than opt.MaxWidth into several tables.
+// Each table has key columns (opt.KeyColumns, default: the first column and row number) and other columns that fit in the width.
+// Unknown key column causes [ErrUnknownColumn] even if the table fits.
+func splitTableColumns(table [][]any, tr *tableRenderer, opt Opt) ([][][]any, error) {
+	if opt.MaxWidth <= 0 || len(table) == 0 {
+		return [][][]any{table}, nil
+	}
+	keyIndexes := make([][]int, len(opt.KeyColumns))
+	for i, name := range opt.KeyColumns {
+		for c, h := range table[0] {
+			if fmt.Sprint(h) == name {
+				keyIndexes[i] = append(keyIndexes[i], c)
+			}
+		}
+		if len(keyIndexes[i]) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+	}
+	maxWidths, _, _ := calcTableSize(table, tr, opt.EastAsianAmbiguousAsWide)
+	// "│" + (" " + cell + " │") * columns
+	width := func(columns []int) int {
+		result := 1
+		for _, c := range columns {
+			result += maxWidths[c] + 3
+		}
+		return result
+	}
+	if width(allColumns(len(maxWidths))) <= opt.MaxWidth {
+		return [][][]any{table}, nil
+	}
+
+	isKey := make([]bool, len(maxWidths))
+	var keys []int
+	addKey := func(c int) {
+		if c < len(isKey) && !isKey[c] {
+			isKey[c] = true
+			keys = append(keys, c)
+		}
+	}
+	if len(opt.KeyColumns) == 0 {
+		addKey(0)
+		if opt.RowNumber {
+			addKey(1)
+		}
+	} else {
+		if opt.RowNumber {
+			addKey(0)
+		}
+		for _, indexes := range keyIndexes {
+			for _, c := range indexes {
+				addKey(c)
+			}
+		}
+	}
+
+	var chunks [][]int
+	current := append([]int{}, keys...)
+	for c := range maxWidths {
+		if isKey[c] {
+			continue
+		}
+		// a chunk has at least one column even if it overflows
+		if len(current) > len(keys) && width(append(current, c)) > opt.MaxWidth {
+			chunks = append(chunks, current)
+			current = append([]int{}, keys...)
+		}
+		current = append(current, c)
+	}
+	if len(current) > len(keys) || len(chunks) == 0 {
+		chunks = append(chunks, current)
+	}
+
+	result := make([][][]any, len(chunks))
+	for i, columns := range chunks {
+		sub := make([][]any, len(table))
+		for r, row := range table {
+			newRow := make([]any, len(columns))
+			for j, c := range columns {
+				if c < len(row) {
+					newRow[j] = row[c]
+				}
+			}
+			sub[r] = newRow
+		}
+		result[i] = sub
+	}
+	return result, nil
+}
+
+func allColumns(count int) []int {
+	result := make([]int, count)
+	for i := range result {
+		result[i] = i
+	}
+	return result
+}
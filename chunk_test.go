@@ -0,0 +1,116 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTableColumns(t *testing.T) {
+	data := []map[string]any{
+		{"id": 1, "cpu": 10, "memory": 2048, "disk": 512, "name": "alpha"},
+		{"id": 2, "cpu": 20, "memory": 4096, "disk": 1024, "name": "beta"},
+	}
+	type args struct {
+		opt Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "first column is repeated",
+			args: args{
+				opt: Opt{MaxWidth: 24},
+			},
+			wantOut: trimIndent(`
+				┌─────┬──────┬────┐
+				│ cpu │ disk │ id │
+				╞═════╪══════╪════╡
+				│ 10  │ 512  │ 1  │
+				├─────┼──────┼────┤
+				│ 20  │ 1024 │ 2  │
+				└─────┴──────┴────┘
+
+				┌─────┬────────┬───────┐
+				│ cpu │ memory │ name  │
+				╞═════╪════════╪═══════╡
+				│ 10  │ 2048   │ alpha │
+				├─────┼────────┼───────┤
+				│ 20  │ 4096   │ beta  │
+				└─────┴────────┴───────┘
+				`),
+		},
+		{
+			name: "key columns",
+			args: args{
+				opt: Opt{MaxWidth: 30, KeyColumns: []string{"name", "id"}, ShowRowCount: true},
+			},
+			wantOut: trimIndent(`
+				┌───────┬────┬─────┬──────┐
+				│ name  │ id │ cpu │ disk │
+				╞═══════╪════╪═════╪══════╡
+				│ alpha │ 1  │ 10  │ 512  │
+				├───────┼────┼─────┼──────┤
+				│ beta  │ 2  │ 20  │ 1024 │
+				└───────┴────┴─────┴──────┘
+
+				┌───────┬────┬────────┐
+				│ name  │ id │ memory │
+				╞═══════╪════╪════════╡
+				│ alpha │ 1  │ 2048   │
+				├───────┼────┼────────┤
+				│ beta  │ 2  │ 4096   │
+				└─ 2 rows ───┴────────┘
+				`),
+		},
+		{
+			name: "table fits",
+			args: args{
+				opt: Opt{MaxWidth: 100, Columns: []string{"id", "name"}},
+			},
+			wantOut: trimIndent(`
+				┌────┬───────┐
+				│ id │ name  │
+				╞════╪═══════╡
+				│ 1  │ alpha │
+				├────┼───────┤
+				│ 2  │ beta  │
+				└────┴───────┘
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			assert.NoError(t, FormatDataWithoutColor(data, out, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestSplitTableColumns_unknownKeyColumn(t *testing.T) {
+	data := []map[string]any{{"id": 1, "name": "alpha"}}
+	tests := []struct {
+		name string
+		opt  Opt
+	}{
+		{
+			name: "table is split",
+			opt:  Opt{MaxWidth: 10, KeyColumns: []string{"nmae"}},
+		},
+		{
+			name: "table fits",
+			opt:  Opt{MaxWidth: 100, KeyColumns: []string{"nmae"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FormatDataWithoutColor(data, &bytes.Buffer{}, tt.opt)
+			assert.ErrorIs(t, err, ErrUnknownColumn)
+			assert.ErrorContains(t, err, "nmae")
+		})
+	}
+}
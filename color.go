@@ -0,0 +1,91 @@
+package formatdata
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// ColorMode controls whether [FormatDataTo] uses escape sequence or not.
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // Default. It uses environment variables and terminal detection.
+	ColorAlways                  // Always uses escape sequence.
+	ColorNever                   // Never uses escape sequence.
+)
+
+var colorModeNames = []string{"auto", "always", "never"}
+
+// String returns "auto", "always" or "never".
+func (m ColorMode) String() string {
+	return enumName(colorModeNames, int(m), "ColorMode")
+}
+
+// Set implements flag.Value. It accepts "auto", "always" and "never".
+func (m *ColorMode) Set(name string) error {
+	v, err := parseEnumName(colorModeNames, name, "color mode")
+	if err != nil {
+		return err
+	}
+	*m = ColorMode(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m ColorMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *ColorMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// useColor decides color output by mode, environment variables and out.
+//
+// Environment variables are evaluated in the following order:
+//
+//   - NO_COLOR (https://no-color.org): non-empty value disables color
+//   - FORCE_COLOR: non-empty value enables color ("0" and "false" disable it)
+//   - CLICOLOR_FORCE: non-empty value except "0" enables color
+//   - TERM: "dumb" disables color
+//   - CLICOLOR: "0" disables color
+//
+// If none of them decides, it uses color only when out is terminal.
+func useColor(out io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if v := os.Getenv("FORCE_COLOR"); v != "" {
+		return v != "0" && v != "false"
+	}
+	if v := os.Getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+	return isTerminal(out)
+}
+
+// detectFormatter returns chroma's formatter name that matches color depth of the terminal.
+func detectFormatter() string {
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return "terminal16m"
+	}
+	if strings.HasSuffix(os.Getenv("TERM"), "-256color") {
+		return "terminal256"
+	}
+	return "terminal"
+}
@@ -0,0 +1,129 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_useColor(t *testing.T) {
+	tests := []struct {
+		name string
+		mode ColorMode
+		env  map[string]string
+		want bool
+	}{
+		{
+			name: "not terminal",
+			want: false,
+		},
+		{
+			name: "always",
+			mode: ColorAlways,
+			env:  map[string]string{"NO_COLOR": "1"},
+			want: true,
+		},
+		{
+			name: "never",
+			mode: ColorNever,
+			env:  map[string]string{"FORCE_COLOR": "1"},
+			want: false,
+		},
+		{
+			name: "NO_COLOR wins",
+			env:  map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"},
+			want: false,
+		},
+		{
+			name: "FORCE_COLOR",
+			env:  map[string]string{"FORCE_COLOR": "true"},
+			want: true,
+		},
+		{
+			name: "FORCE_COLOR=0",
+			env:  map[string]string{"FORCE_COLOR": "0", "CLICOLOR_FORCE": "1"},
+			want: false,
+		},
+		{
+			name: "CLICOLOR_FORCE",
+			env:  map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM"} {
+				t.Setenv(k, tt.env[k])
+			}
+			assert.Equal(t, tt.want, useColor(&bytes.Buffer{}, tt.mode))
+		})
+	}
+}
+
+func Test_detectFormatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		colorTerm string
+		term      string
+		want      string
+	}{
+		{
+			name:      "truecolor",
+			colorTerm: "truecolor",
+			term:      "xterm-256color",
+			want:      "terminal16m",
+		},
+		{
+			name:      "24bit",
+			colorTerm: "24bit",
+			want:      "terminal16m",
+		},
+		{
+			name: "256 colors",
+			term: "screen-256color",
+			want: "terminal256",
+		},
+		{
+			name: "others",
+			term: "xterm",
+			want: "terminal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLORTERM", tt.colorTerm)
+			t.Setenv("TERM", tt.term)
+			assert.Equal(t, tt.want, detectFormatter())
+		})
+	}
+}
+
+func TestColorDetection_sharedByPublicFunctions(t *testing.T) {
+	data := []SampleStruct{{A: "x", B: 1}}
+	functions := map[string]func(out *bytes.Buffer) error{
+		"FormatDataTo": func(out *bytes.Buffer) error {
+			return FormatDataTo(data, out)
+		},
+		"FormatDiff": func(out *bytes.Buffer) error {
+			return FormatDiff(data, data, out)
+		},
+		"FormatTables": func(out *bytes.Buffer) error {
+			return FormatTables(out, []NamedTable{{Data: data}})
+		},
+	}
+	for name, f := range functions {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("FORCE_COLOR", "1")
+			var out bytes.Buffer
+			assert.NoError(t, f(&out))
+			assert.Contains(t, out.String(), "\033[")
+
+			t.Setenv("NO_COLOR", "1")
+			out.Reset()
+			assert.NoError(t, f(&out))
+			assert.NotContains(t, out.String(), "\033[")
+		})
+	}
+}
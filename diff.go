@@ -0,0 +1,219 @@
+package formatdata
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FormatDiff shows difference between before and after.
+//
+// If both data can be represented as table, rows are matched by key column ([Opt.DiffKey]) and
+// added, removed and changed rows are shown with "+"/"-" gutter. Unknown key column causes [ErrUnknownColumn].
+// Otherwise, or if rows have duplicated keys, it shows unified diff of YAML (or JSON if [JSON] is specified).
+//
+// Color output is decided in the same way as [FormatDataTo].
+func FormatDiff(before, after any, out io.Writer, o ...Opt) error {
+	ctx, err := detectRenderContext(out, o)
+	if err != nil {
+		return err
+	}
+	return renderDiff(before, after, out, ctx)
+}
+
+// FormatDiffWithColor is [FormatDiff]'s variation that always uses escape sequence to dump colorized output.
+func FormatDiffWithColor(before, after any, out io.Writer, o ...Opt) error {
+	ctx, err := newRenderContext(out, o, true)
+	if err != nil {
+		return err
+	}
+	return renderDiff(before, after, out, ctx)
+}
+
+// FormatDiffWithoutColor is [FormatDiff]'s variation that always doesn't use escape sequence.
+func FormatDiffWithoutColor(before, after any, out io.Writer, o ...Opt) error {
+	ctx, _ := newRenderContext(out, o, false)
+	return renderDiff(before, after, out, ctx)
+}
+
+// renderDiff uses table renderer of the output format if both data can be represented as table.
+func renderDiff(before, after any, out io.Writer, ctx RenderContext) error {
+	r, err := findRenderer(ctx.Opt.OutputFormat)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.(ValueRenderer); !ok {
+		cells, ok, err := diffTable(before, after, ctx.Opt.DiffKey, ctx.Opt.Columns)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return r.Render(exportTable(r, cells, ctx.Opt), out, ctx)
+		}
+	}
+	diff, err := unifiedDiff(before, after, ctx.Opt)
+	if err != nil {
+		return err
+	}
+	return ctx.Highlight(out, diff, "diff")
+}
+
+func unifiedDiff(before, after any, opt Opt) (string, error) {
+	encode := func(data any) ([]string, error) {
+		format := YAML
+		if opt.OutputFormat == JSON {
+			format = JSON
+		}
+		src, err := encodeData(data, format, opt.Indent)
+		if err != nil {
+			return nil, err
+		}
+		lines := strings.SplitAfter(src, "\n")
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		return lines, nil
+	}
+	a, err := encode(before)
+	if err != nil {
+		return "", err
+	}
+	b, err := encode(after)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
+}
+
+// diffTable creates table that has gutter column at first.
+// It returns false if data is not table or rows can't be matched because of duplicated keys.
+func diffTable(before, after any, key string, columns []string) ([][]any, bool, error) {
+	beforeCells, ok := canBeTable(before)
+	if !ok || len(beforeCells) == 0 {
+		return nil, false, nil
+	}
+	afterCells, ok := canBeTable(after)
+	if !ok || len(afterCells) == 0 {
+		return nil, false, nil
+	}
+	beforeCells = selectColumns(beforeCells, columns)
+	afterCells = selectColumns(afterCells, columns)
+
+	var headers []string
+	existingCheck := map[string]bool{}
+	for _, h := range append(append([]any{}, beforeCells[0]...), afterCells[0]...) {
+		name := fmt.Sprint(h)
+		if !existingCheck[name] {
+			headers = append(headers, name)
+			existingCheck[name] = true
+		}
+	}
+	keyIndex := -1
+	for i, h := range headers {
+		if h == key {
+			keyIndex = i
+		}
+	}
+	if keyIndex == -1 {
+		if key != "" {
+			return nil, false, fmt.Errorf("%w: %s", ErrUnknownColumn, key)
+		}
+		keyIndex = 0
+	}
+	toRows := func(cells [][]any) []map[string]any {
+		rows := make([]map[string]any, len(cells)-1)
+		for r, row := range cells[1:] {
+			rows[r] = map[string]any{}
+			for c, v := range row {
+				if c < len(cells[0]) {
+					rows[r][fmt.Sprint(cells[0][c])] = v
+				}
+			}
+		}
+		return rows
+	}
+	beforeRows := toRows(beforeCells)
+	afterRows := toRows(afterCells)
+	keyOf := func(row map[string]any) string {
+		return fmt.Sprint(row[headers[keyIndex]])
+	}
+	beforeIndex := map[string]int{}
+	for i, row := range beforeRows {
+		if _, ok := beforeIndex[keyOf(row)]; ok {
+			return nil, false, nil
+		}
+		beforeIndex[keyOf(row)] = i
+	}
+	afterKeys := map[string]bool{}
+	for _, row := range afterRows {
+		if afterKeys[keyOf(row)] {
+			return nil, false, nil
+		}
+		afterKeys[keyOf(row)] = true
+	}
+
+	headerRow := []any{""}
+	for _, h := range headers {
+		headerRow = append(headerRow, h)
+	}
+	result := [][]any{headerRow}
+	addRow := func(gutter string, row, other map[string]any, token chroma.TokenType) {
+		newRow := []any{gutter}
+		if gutter != " " {
+			newRow[0] = styledCell{value: gutter, token: token}
+		}
+		for _, h := range headers {
+			v, ok := row[h]
+			if !ok {
+				v = missingCell{}
+			}
+			if other == nil {
+				if gutter != " " {
+					v = styledCell{value: v, token: token}
+				}
+			} else if ov, ok := other[h]; !ok || !reflect.DeepEqual(v, ov) {
+				v = styledCell{value: v, token: token}
+			}
+			newRow = append(newRow, v)
+		}
+		result = append(result, newRow)
+	}
+	addRemovedRows := func(rows []map[string]any) {
+		for _, row := range rows {
+			if !afterKeys[keyOf(row)] {
+				addRow("-", row, nil, chroma.GenericDeleted)
+			}
+		}
+	}
+
+	next := 0
+	for _, row := range afterRows {
+		i, ok := beforeIndex[keyOf(row)]
+		if !ok {
+			addRow("+", row, nil, chroma.GenericInserted)
+			continue
+		}
+		if i >= next {
+			addRemovedRows(beforeRows[next:i])
+			next = i + 1
+		}
+		if old := beforeRows[i]; reflect.DeepEqual(old, row) {
+			addRow(" ", row, nil, chroma.Text)
+		} else {
+			addRow("-", old, row, chroma.GenericDeleted)
+			addRow("+", row, old, chroma.GenericInserted)
+		}
+	}
+	addRemovedRows(beforeRows[next:])
+	return result, true, nil
+}
@@ -0,0 +1,146 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDiff(t *testing.T) {
+	type args struct {
+		before any
+		after  any
+		opt    Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "table: added, removed and changed rows",
+			args: args{
+				before: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}, {A: "z", B: 3}},
+				after:  []SampleStruct{{A: "x", B: 1}, {A: "z", B: 4}, {A: "w", B: 5}},
+				opt:    Opt{DiffKey: "a"},
+			},
+			wantOut: trimIndent(`
+				┌───┬───┬───┐
+				│   │ a │ b │
+				╞═══╪═══╪═══╡
+				│   │ x │ 1 │
+				├───┼───┼───┤
+				│ - │ y │ 2 │
+				├───┼───┼───┤
+				│ - │ z │ 3 │
+				├───┼───┼───┤
+				│ + │ z │ 4 │
+				├───┼───┼───┤
+				│ + │ w │ 5 │
+				└───┴───┴───┘
+				`),
+		},
+		{
+			name: "table: null and missing cells are shown with markers",
+			args: args{
+				before: []map[string]any{{"id": 1, "v": nil}, {"id": 2, "v": "x"}},
+				after:  []map[string]any{{"id": 1, "v": "y"}, {"id": 2}},
+				opt:    Opt{NullMarker: "null"},
+			},
+			wantOut: trimIndent(`
+				┌───┬────┬──────┐
+				│   │ id │ v    │
+				╞═══╪════╪══════╡
+				│ - │ 1  │ null │
+				├───┼────┼──────┤
+				│ + │ 1  │ y    │
+				├───┼────┼──────┤
+				│ - │ 2  │ x    │
+				├───┼────┼──────┤
+				│ + │ 2  │ —    │
+				└───┴────┴──────┘
+				`),
+		},
+		{
+			name: "table: duplicated keys fall back to unified diff",
+			args: args{
+				before: []SampleStruct{{A: "x", B: 1}, {A: "x", B: 2}},
+				after:  []SampleStruct{{A: "x", B: 1}},
+				opt:    Opt{DiffKey: "a"},
+			},
+			wantOut: trimIndent(`
+				--- before
+				+++ after
+				@@ -1,4 +1,2 @@
+				 - a: x
+				   b: 1
+				-- a: x
+				-  b: 2
+				`),
+		},
+		{
+			name: "not table: unified YAML diff",
+			args: args{
+				before: map[string]any{"a": 1, "b": 2},
+				after:  map[string]any{"a": 1, "b": 3},
+			},
+			wantOut: trimIndent(`
+				--- before
+				+++ after
+				@@ -1,2 +1,2 @@
+				 a: 1
+				-b: 2
+				+b: 3
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := FormatDiffWithoutColor(tt.args.before, tt.args.after, out, tt.args.opt)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func Test_diffTable_highlightsChangedCells(t *testing.T) {
+	cells, ok, err := diffTable(
+		[]SampleStruct{{A: "x", B: 1}},
+		[]SampleStruct{{A: "x", B: 2}},
+		"a", nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, [][]any{
+		{"", "a", "b"},
+		{styledCell{"-", chroma.GenericDeleted}, "x", styledCell{1, chroma.GenericDeleted}},
+		{styledCell{"+", chroma.GenericInserted}, "x", styledCell{2, chroma.GenericInserted}},
+	}, cells)
+}
+
+func TestFormatDiff_unknownKey(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := FormatDiffWithoutColor(
+		[]SampleStruct{{A: "x", B: 1}},
+		[]SampleStruct{{A: "x", B: 2}},
+		out, Opt{DiffKey: "c"})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+	assert.Contains(t, err.Error(), "c")
+	assert.Empty(t, out.String())
+}
+
+func Test_diffTable_missingColumn(t *testing.T) {
+	cells, ok, err := diffTable(
+		[]map[string]any{{"a": "x", "b": 1}},
+		[]map[string]any{{"a": "y"}},
+		"a", nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, [][]any{
+		{"", "a", "b"},
+		{styledCell{"+", chroma.GenericInserted}, styledCell{"y", chroma.GenericInserted}, styledCell{missingCell{}, chroma.GenericInserted}},
+		{styledCell{"-", chroma.GenericDeleted}, styledCell{"x", chroma.GenericDeleted}, styledCell{1, chroma.GenericDeleted}},
+	}, cells, "absent keys are missing cells, not empty strings")
+}
@@ -0,0 +1,148 @@
+package formatdata
+
+import (
+	"io"
+
+	"github.com/shibukawa/stringwidth"
+	"gopkg.in/yaml.v3"
+)
+
+// documentWriter renders object as document (FallbackDocument): scalar fields become key/value header
+// and tabular fields become titled tables. Nested objects are rendered as sections until [Opt.DocumentDepth].
+type documentWriter struct {
+	w        *tableWriter
+	out      io.Writer
+	ctx      RenderContext
+	renderer Renderer
+	tree     treeWriter
+	markdown bool
+}
+
+func renderDocument(data any, out io.Writer, ctx RenderContext, r Renderer) error {
+	node, err := encodeYAMLNode(data)
+	if err != nil {
+		return err
+	}
+	if node.Kind != yaml.MappingNode {
+		ctx.Opt.Fallback = FallbackYAML
+		return renderFallback(data, out, ctx, r)
+	}
+	tr := ctx.tableRenderer()
+	d := documentWriter{
+		w:        newTableWriter(out),
+		out:      out,
+		ctx:      ctx,
+		renderer: r,
+		tree:     treeWriter{tr: tr, palette: ctx.Opt.Palette.withDefault()},
+		markdown: ctx.Opt.OutputFormat == Markdown,
+	}
+	return d.section(node, "", 1)
+}
+
+func (d *documentWriter) section(node *yaml.Node, path string, depth int) error {
+	var keys []string
+	var values []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+		values = append(values, node.Content[i+1])
+	}
+	// header
+	var width int
+	for i, k := range keys {
+		if isDocumentScalar(values[i]) {
+			if w := stringwidth.Calc(k, stringwidth.Opt{IsAmbiguousWide: d.ctx.Opt.EastAsianAmbiguousAsWide}); w > width {
+				width = w
+			}
+		}
+	}
+	wroteBlock := false
+	for i, k := range keys {
+		if !isDocumentScalar(values[i]) {
+			continue
+		}
+		if d.markdown {
+			d.w.WriteString("- ")
+		}
+		d.w.WriteString(d.tree.tr.stringCell(k, true))
+		d.w.WriteString(d.tree.tr.border(":") + " ")
+		if !d.markdown {
+			d.w.Repeat(" ", width-stringwidth.Calc(k, stringwidth.Opt{IsAmbiguousWide: d.ctx.Opt.EastAsianAmbiguousAsWide}))
+		}
+		d.w.WriteString(d.scalar(values[i]) + "\n")
+		wroteBlock = true
+	}
+	if d.w.err != nil {
+		return d.w.err
+	}
+	// children
+	for i, k := range keys {
+		v := values[i]
+		if isDocumentScalar(v) {
+			continue
+		}
+		title := k
+		if path != "" {
+			title = path + "." + k
+		}
+		var data any
+		if err := v.Decode(&data); err != nil {
+			return err
+		}
+		cells, isTable := canBeTable(data)
+		if v.Kind == yaml.MappingNode && !isTable && depth < d.ctx.Opt.DocumentDepth {
+			if wroteBlock {
+				d.w.WriteString("\n")
+			}
+			d.title(title, depth)
+			if err := d.section(v, title, depth+1); err != nil {
+				return err
+			}
+			wroteBlock = true
+			continue
+		}
+		if wroteBlock {
+			d.w.WriteString("\n")
+		}
+		d.title(title, depth)
+		if d.w.err != nil {
+			return d.w.err
+		}
+		var err error
+		if isTable {
+			err = d.renderer.Render(exportTable(d.renderer, cells, d.ctx.Opt), d.out, d.ctx)
+		} else {
+			// node keeps order of fields
+			ctx := d.ctx
+			ctx.Opt.Fallback = FallbackYAML
+			err = renderFallback(v, d.out, ctx, d.renderer)
+		}
+		if err != nil {
+			return err
+		}
+		wroteBlock = true
+	}
+	return d.w.err
+}
+
+func (d *documentWriter) title(title string, depth int) {
+	writeTitle(d.w, d.tree.tr, title, depth+2, d.markdown)
+}
+
+func (d *documentWriter) scalar(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return d.tree.tr.border("[]")
+	case yaml.MappingNode:
+		return d.tree.tr.border("{}")
+	}
+	return d.tree.scalar(node)
+}
+
+// isDocumentScalar returns true if the node is shown in key/value header. Empty collections are also in header.
+func isDocumentScalar(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(node.Content) == 0
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleCluster struct {
+	Cluster string         `yaml:"cluster"`
+	Version int            `yaml:"version"`
+	Nodes   []SampleStruct `yaml:"nodes"`
+	Meta    sampleMeta     `yaml:"meta"`
+}
+
+type sampleMeta struct {
+	Owner  string   `yaml:"owner"`
+	Tags   []string `yaml:"tags"`
+	Labels []map[string]string
+}
+
+func TestDocument(t *testing.T) {
+	data := sampleCluster{
+		Cluster: "x",
+		Version: 12,
+		Nodes:   []SampleStruct{{A: "a", B: 1}, {A: "b", B: 2}},
+		Meta: sampleMeta{
+			Owner:  "ops",
+			Tags:   []string{"prod"},
+			Labels: []map[string]string{{"key": "env", "value": "prod"}},
+		},
+	}
+	type args struct {
+		data any
+		opt  Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "terminal",
+			args: args{
+				data: data,
+				opt:  Opt{},
+			},
+			wantOut: trimIndent(`
+				cluster: x
+				version: 12
+
+				nodes
+				┌───┬───┐
+				│ a │ b │
+				╞═══╪═══╡
+				│ a │ 1 │
+				├───┼───┤
+				│ b │ 2 │
+				└───┴───┘
+
+				meta
+				owner: ops
+
+				meta.tags
+				- prod
+
+				meta.labels
+				┌─────┬───────┐
+				│ key │ value │
+				╞═════╪═══════╡
+				│ env │ prod  │
+				└─────┴───────┘
+				`),
+		},
+		{
+			name: "depth",
+			args: args{
+				data: data,
+				opt:  Opt{DocumentDepth: 1},
+			},
+			wantOut: trimIndent(`
+				cluster: x
+				version: 12
+
+				nodes
+				┌───┬───┐
+				│ a │ b │
+				╞═══╪═══╡
+				│ a │ 1 │
+				├───┼───┤
+				│ b │ 2 │
+				└───┴───┘
+
+				meta
+				owner: ops
+				tags:
+				  - prod
+				labels:
+				  - key: env
+				    value: prod
+				`),
+		},
+		{
+			name: "markdown",
+			args: args{
+				data: map[string]any{"name": "x", "items": []SampleStruct{{A: "a", B: 1}}},
+				opt:  Opt{OutputFormat: Markdown},
+			},
+			wantOut: trimIndent(`
+				- name: x
+
+				### items
+
+				| a | b |
+				|---|---|
+				| a | 1 |
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.args.opt.Fallback = FallbackDocument
+			assert.NoError(t, FormatDataWithoutColor(tt.args.data, out, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package formatdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ErrUnsupportedType is returned when data contains values that can't be formatted like channels and functions.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrUnknownFormat is returned when [Opt.OutputFormat] is not registered.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// ErrNotTable is returned when data can't be represented as table and [Opt.Fallback] is FallbackError.
+var ErrNotTable = errors.New("data is not tabular")
+
+// ErrUnknownColumn is returned when [Opt.DiffKey] or [Opt.KeyColumns] is not a column of the table.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// WriteError is returned when writing to output fails during rendering tables.
+//
+// Row is an index of table rows (0 is header). Column is an index of columns. They are -1 if the error
+// happens outside cells (e.g. borders).
+type WriteError struct {
+	Row    int
+	Column int
+	Err    error
+}
+
+func (e *WriteError) Error() string {
+	if e.Column < 0 {
+		return fmt.Sprintf("write error at row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("write error at row %d, column %d: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// tableWriter keeps the first write error with current position. After error, it ignores all writes.
+type tableWriter struct {
+	out    io.Writer
+	row    int
+	column int
+	err    error
+}
+
+func newTableWriter(out io.Writer) *tableWriter {
+	return &tableWriter{out: out, column: -1}
+}
+
+func (w *tableWriter) WriteString(s string) {
+	if w.err != nil || s == "" {
+		return
+	}
+	if _, err := io.WriteString(w.out, s); err != nil {
+		w.err = &WriteError{Row: w.row, Column: w.column, Err: err}
+	}
+}
+
+func (w *tableWriter) Repeat(s string, count int) {
+	for i := 0; i < count; i++ {
+		w.WriteString(s)
+	}
+}
+
+// checkCellTypes returns ErrUnsupportedType if table has channels, functions and so on.
+func checkCellTypes(table [][]any) error {
+	for r, row := range table {
+		for c, v := range row {
+			switch cell := v.(type) {
+			case styledCell:
+				v = cell.value
+			case ruledCell:
+				v = cell.value
+			}
+			switch reflect.ValueOf(v).Kind() {
+			case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+				return fmt.Errorf("%w: %T at row %d, column %d", ErrUnsupportedType, v, r, c)
+			}
+		}
+	}
+	return nil
+}
+
+// yamlUnsupportedTypePrefix is a prefix of yaml.v3's panic message for types it can't marshal (e.g. channels).
+const yamlUnsupportedTypePrefix = "cannot marshal type: "
+
+// yamlPanicError converts panic of YAML encoder into error.
+// Only the panic for unsupported types becomes ErrUnsupportedType. Other errors are returned as they are.
+func yamlPanicError(r any) error {
+	switch v := r.(type) {
+	case error:
+		return v
+	case string:
+		if strings.HasPrefix(v, yamlUnsupportedTypePrefix) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, strings.TrimPrefix(v, yamlUnsupportedTypePrefix))
+		}
+	}
+	return fmt.Errorf("yaml: %v", r)
+}
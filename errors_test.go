@@ -0,0 +1,111 @@
+package formatdata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failWriter struct {
+	limit int
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.limit < len(p) {
+		return 0, errWriteFailed
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestFormatData_UnsupportedType(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		opt  Opt
+	}{
+		{
+			name: "channel in YAML",
+			data: map[string]any{"a": make(chan int)},
+			opt:  Opt{OutputFormat: YAML},
+		},
+		{
+			name: "function in JSON",
+			data: map[string]any{"a": func() {}},
+			opt:  Opt{OutputFormat: JSON},
+		},
+		{
+			name: "function in table",
+			data: [][]any{{"a"}, {func() {}}},
+		},
+		{
+			name: "channel in fallback",
+			data: map[string]any{"a": make(chan int)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := FormatDataWithoutColor(tt.data, &out, tt.opt)
+			assert.ErrorIs(t, err, ErrUnsupportedType)
+			assert.Equal(t, "", out.String())
+
+			err = FormatDataWithColor(tt.data, &out, tt.opt)
+			assert.ErrorIs(t, err, ErrUnsupportedType)
+			assert.Equal(t, "", out.String())
+		})
+	}
+}
+
+func TestFormatData_WriteError(t *testing.T) {
+	data := [][]string{{"AAAA", "BBB"}, {"12", "123"}}
+
+	// top border (3+6+3+5 bytes) and first cell are written
+	err := FormatDataWithoutColor(data, &failWriter{limit: len("┌──────┬─────┐\n│ AAAA")}, Opt{})
+	var we *WriteError
+	assert.ErrorAs(t, err, &we)
+	assert.Equal(t, 0, we.Row)
+	assert.Equal(t, 0, we.Column)
+	assert.ErrorIs(t, err, errWriteFailed)
+
+	err = FormatDataWithoutColor(data, &failWriter{limit: 10}, Opt{OutputFormat: Markdown})
+	assert.ErrorAs(t, err, &we)
+
+	err = FormatDataWithoutColor(data, &failWriter{limit: 10}, Opt{OutputFormat: YAML})
+	assert.ErrorIs(t, err, errWriteFailed)
+}
+
+type failMarshaler struct{}
+
+var errMarshalFailed = errors.New("marshal failed")
+
+func (failMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errMarshalFailed
+}
+
+func (failMarshaler) MarshalYAML() (any, error) {
+	return nil, errMarshalFailed
+}
+
+func TestFormatData_EncodeError(t *testing.T) {
+	for _, format := range []OutputFormat{JSON, YAML, JSONLines} {
+		t.Run(format.String(), func(t *testing.T) {
+			var out bytes.Buffer
+			err := FormatDataWithoutColor(map[string]any{"a": failMarshaler{}}, &out, Opt{OutputFormat: format})
+			assert.ErrorIs(t, err, errMarshalFailed)
+			assert.NotErrorIs(t, err, ErrUnsupportedType)
+		})
+	}
+}
+
+func Test_yamlPanicError(t *testing.T) {
+	assert.ErrorIs(t, yamlPanicError("cannot marshal type: chan int"), ErrUnsupportedType)
+	assert.Equal(t, errMarshalFailed, yamlPanicError(errMarshalFailed))
+	err := yamlPanicError("something wrong")
+	assert.NotErrorIs(t, err, ErrUnsupportedType)
+	assert.EqualError(t, err, "yaml: something wrong")
+}
@@ -9,7 +9,7 @@ type SampleStruct struct {
 	B int    `json:"b"`
 }
 
-func ExampleFormatData_TableStyle() {
+func ExampleFormatData_tableStyle() {
 	// If the data can be represented as table, it shows in table format
 	tableData := []SampleStruct{
 		{
@@ -32,7 +32,7 @@ func ExampleFormatData_TableStyle() {
 	// └───┴───┘
 }
 
-func ExampleFormatData_MarkdownTable() {
+func ExampleFormatData_markdownTable() {
 	// If the data can be represented as table and Markdown is specified,
 	// it shows in Markdown table format. Fallback format is YAML.
 	tableData := []SampleStruct{
@@ -55,7 +55,7 @@ func ExampleFormatData_MarkdownTable() {
 	// | b | 2 |
 }
 
-func ExampleFormatData_YAML() {
+func ExampleFormatData_yaml() {
 	// it shows in YAML format
 	tableData := []SampleStruct{
 		{
@@ -77,7 +77,7 @@ func ExampleFormatData_YAML() {
 	//   b: 2
 }
 
-func ExampleFormatData_JSON() {
+func ExampleFormatData_json() {
 	// it shows in JSON format
 	tableData := []SampleStruct{
 		{
@@ -0,0 +1,75 @@
+package formatdata
+
+import (
+	"fmt"
+	"io"
+)
+
+// Fallback is a format for data that can't be represented as table in table formats (Terminal, Markdown and
+// custom formats that don't implement [ValueRenderer]).
+type Fallback int
+
+const (
+	FallbackYAML     Fallback = iota // Default.
+	FallbackJSON                     // JSON.
+	FallbackTree                     // Same as Tree output format.
+	FallbackError                    // Returns ErrNotTable.
+	FallbackDocument                 // Scalar fields as key/value header and tabular fields as titled tables.
+)
+
+var fallbackNames = []string{"yaml", "json", "tree", "error", "document"}
+
+// String returns "yaml", "json", "tree", "error" or "document".
+func (f Fallback) String() string {
+	return enumName(fallbackNames, int(f), "Fallback")
+}
+
+// Set implements flag.Value. It accepts "yaml", "json", "tree", "error" and "document".
+func (f *Fallback) Set(name string) error {
+	v, err := parseEnumName(fallbackNames, name, "fallback")
+	if err != nil {
+		return err
+	}
+	*f = Fallback(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Fallback) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Fallback) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// renderFallback renders non-tabular data by [Opt.Fallback]. tr is a renderer of the original format used for tables in document.
+// In Markdown, it is wrapped with fenced code block to keep output valid Markdown.
+func renderFallback(data any, out io.Writer, ctx RenderContext, tr Renderer) error {
+	var r ValueRenderer
+	var lang string
+	switch ctx.Opt.Fallback {
+	case FallbackDocument:
+		return renderDocument(data, out, ctx, tr)
+	case FallbackJSON:
+		r, lang = jsonRenderer{}, "json"
+	case FallbackTree:
+		r, lang = treeRenderer{}, "text"
+	case FallbackError:
+		return fmt.Errorf("%w: %T", ErrNotTable, data)
+	default:
+		r, lang = yamlRenderer{}, "yaml"
+	}
+	if ctx.Opt.OutputFormat != Markdown {
+		return r.RenderValue(data, out, ctx)
+	}
+	if _, err := io.WriteString(out, "```"+lang+"\n"); err != nil {
+		return err
+	}
+	if err := r.RenderValue(data, out, ctx); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "```\n")
+	return err
+}
@@ -0,0 +1,24 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallback(t *testing.T) {
+	var f Fallback
+	assert.NoError(t, f.Set("Tree"))
+	assert.Equal(t, FallbackTree, f)
+	assert.Equal(t, "tree", f.String())
+	assert.Error(t, f.Set("xml"))
+
+	err := FormatDataWithoutColor([]string{"a"}, &bytes.Buffer{}, Opt{Fallback: FallbackError})
+	assert.ErrorIs(t, err, ErrNotTable)
+
+	// value formats don't use fallback
+	out := &bytes.Buffer{}
+	assert.NoError(t, FormatDataWithoutColor([]string{"a"}, out, Opt{OutputFormat: JSON, Fallback: FallbackError}))
+	assert.Equal(t, "[\n  \"a\"\n]\n", out.String())
+}
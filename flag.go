@@ -0,0 +1,91 @@
+package formatdata
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RegisterFlags registers command line flags to fs. Parsed values are stored into o.
+//
+//   - --output: output format (see [ParseOutputFormat])
+//   - --style: chroma's style name, "auto" or style file
+//   - --columns: comma separated column names
+//   - --no-color: disables color
+func (o *Opt) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(&o.OutputFormat, "output", "output format: "+strings.Join(FormatNames(), ", "))
+	fs.StringVar(&o.Style, "style", o.Style, `color style name, "auto" or style file path`)
+	fs.Var((*columnsValue)(&o.Columns), "columns", "comma separated column names to show")
+	fs.Var((*noColorValue)(&o.Color), "no-color", "disable color output")
+}
+
+// FormatNames returns names of all output formats.
+func FormatNames() []string {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	result := make([]string, len(formats))
+	for i, f := range formats {
+		result[i] = f.name
+	}
+	return result
+}
+
+type columnsValue []string
+
+func (c *columnsValue) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *columnsValue) Set(s string) error {
+	*c = nil
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*c = append(*c, name)
+		}
+	}
+	return nil
+}
+
+type noColorValue ColorMode
+
+func (n *noColorValue) String() string {
+	return ColorMode(*n).String()
+}
+
+func (n *noColorValue) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if v {
+		*n = noColorValue(ColorNever)
+	} else {
+		*n = noColorValue(ColorAuto)
+	}
+	return nil
+}
+
+func (n *noColorValue) IsBoolFlag() bool {
+	return true
+}
+
+// enumName returns names[v] for String method of enum types like [ColorMode]. Unknown value is "TypeName(v)".
+func enumName(names []string, v int, typeName string) string {
+	if v >= 0 && v < len(names) {
+		return names[v]
+	}
+	return fmt.Sprintf("%s(%d)", typeName, v)
+}
+
+// parseEnumName returns index of s in names case-insensitively for Set method of enum types.
+// kind is used in error message like `invalid color mode "x" (auto, always or never)`.
+func parseEnumName(names []string, s, kind string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(n, s) {
+			return i, nil
+		}
+	}
+	choices := strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	return 0, fmt.Errorf("invalid %s %q (%s)", kind, s, choices)
+}
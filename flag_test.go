@@ -0,0 +1,87 @@
+package formatdata
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{name: "terminal", want: Terminal},
+		{name: "table", want: Terminal},
+		{name: "Markdown", want: Markdown},
+		{name: "json", want: JSON},
+		{name: "yml", want: YAML},
+		{name: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOutputFormat(tt.name)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownFormat)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+	assert.Equal(t, "markdown", Markdown.String())
+	assert.Equal(t, "OutputFormat(1000)", OutputFormat(1000).String())
+}
+
+func TestOutputFormat_UnmarshalText(t *testing.T) {
+	var config struct {
+		Output OutputFormat `json:"output"`
+		Color  ColorMode    `json:"color"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"output": "yaml", "color": "always"}`), &config))
+	assert.Equal(t, YAML, config.Output)
+	assert.Equal(t, ColorAlways, config.Color)
+
+	b, err := json.Marshal(config)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"output":"yaml","color":"always"}`, string(b))
+}
+
+func TestOpt_RegisterFlags(t *testing.T) {
+	var opt Opt
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	opt.RegisterFlags(fs)
+	err := fs.Parse([]string{"--output", "md", "--style", "github", "--columns", "name, age", "--no-color"})
+	assert.NoError(t, err)
+	assert.Equal(t, Opt{
+		OutputFormat: Markdown,
+		Style:        "github",
+		Columns:      []string{"name", "age"},
+		Color:        ColorNever,
+	}, opt)
+
+	err = fs.Parse([]string{"--output", "unknown"})
+	assert.Error(t, err)
+}
+
+func Test_enumNames(t *testing.T) {
+	names := []string{"auto", "always", "never"}
+	assert.Equal(t, "always", enumName(names, 1, "ColorMode"))
+	assert.Equal(t, "ColorMode(5)", enumName(names, 5, "ColorMode"))
+
+	v, err := parseEnumName(names, "NEVER", "color mode")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	_, err = parseEnumName(names, "sometimes", "color mode")
+	assert.EqualError(t, err, `invalid color mode "sometimes" (auto, always or never)`)
+
+	// all enum types share the helpers
+	var f Fallback
+	assert.EqualError(t, f.Set("csv"), `invalid fallback "csv" (yaml, json, tree, error or document)`)
+	assert.Equal(t, "PagerMode(9)", PagerMode(9).String())
+}
@@ -4,29 +4,114 @@ package formatdata
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"strings"
 
-	"github.com/alecthomas/chroma/v2/quick"
+	"github.com/alecthomas/chroma/v2"
 	"github.com/mattn/go-colorable"
 	"gopkg.in/yaml.v3"
 )
 
-type OutputFormat = int
+// OutputFormat is a format of output. Formats added by [RegisterFormat] are also available.
+type OutputFormat int
 
 const (
-	Terminal OutputFormat = iota // Default. If data is not grid compatible, fallback to YAML.
-	Markdown                     // Markdown table. If data is not grid compatible, fallback to YAML.
+	Terminal OutputFormat = iota // Default. If data is not grid compatible, fallback to Opt.Fallback (default: YAML).
+	Markdown                     // Markdown table. If data is not grid compatible, fallback to Opt.Fallback in fenced code block.
 	JSON
 	YAML
+	JSONLines   // JSON Lines (NDJSON). One compact JSON per slice (or channel) element.
+	TOML        // TOML. Top level slice becomes array of tables ([[items]]).
+	GoSyntax    // Go's composite literal like %#v, but indented by gofmt.
+	Tree        // Tree view with guide lines. Long arrays are collapsed by Opt.TreeMaxItems.
+	Interactive // Full-screen table browser if output is terminal. Otherwise, same as Terminal.
 )
 
 type Opt struct {
 	OutputFormat             OutputFormat
 	EastAsianAmbiguousAsWide bool
-	Formatter                string // "terminal", "terminal8", "terminal16", "terminal256", "terminal16m". Default: "terminal"
-	Style                    string // https://github.com/alecthomas/chroma/tree/master/styles. Default: "monokai"
-	Indent                   int    // Indent for JSON/YAML
+	Formatter                string        // "terminal", "terminal8", "terminal16", "terminal256", "terminal16m". Default: detected from environment variables, or "terminal"
+	Style                    string        // https://github.com/alecthomas/chroma/tree/master/styles, "auto", "dark=monokai,light=github" or style file path (see LoadStyle). Default: "monokai"
+	Indent                   int           // Indent for JSON/YAML
+	RowNumber                bool          // Add "#" column that has row index to the table
+	RowNumberStart           *int          // Start value of row index. Default: 1 (nil)
+	DiffKey                  string        // Column name to match rows in FormatDiff. Default: first column
+	StyleRules               []StyleRule   // Conditions to highlight table cells
+	ShowRuleMarker           bool          // Add StyleRule's marker to matched cells in output without color
+	Palette                  Palette       // Token types of table elements. Default: DefaultPalette
+	Color                    ColorMode     // ColorAuto(default), ColorAlways, ColorNever
+	FillBackground           bool          // Paint table with style's background color
+	ChromaStyle              *chroma.Style // Custom style. It overrides Style
+	Columns                  []string      // Column names to show in this order. Default: all columns
+	TreeMaxItems             int           // Max items of each array in Tree view. Rest items are collapsed. Default: 20 (0). -1: never collapse
+	Fallback                 Fallback      // Format for non-tabular data in table formats: FallbackYAML(default), FallbackJSON, FallbackTree, FallbackError, FallbackDocument
+	DocumentDepth            int           // Max depth of nested objects rendered as sections in FallbackDocument. Default: 3
+	Title                    string        // Title embedded in the top border of Terminal table
+	ShowRowCount             bool          // Show row count in the bottom border of Terminal table
+	Pager                    PagerMode     // PagerAuto(default), PagerAlways, PagerNever
+	RepeatHeader             int           // Repeat header row every N rows in Terminal table. RepeatHeaderByScreen repeats it every terminal height
+	MaxWidth                 int           // Terminal table wider than this is split into several tables by columns. Default: 0 (no limit)
+	KeyColumns               []string      // Columns repeated in each split table. Default: the first column
+	NullMarker               string        // Text of null cells in Terminal/Markdown table. Default: ""
+	MissingMarker            string        // Text of cells whose key doesn't exist in the row. Default: "—"
+}
+
+var formatAliases = map[string]OutputFormat{
+	"table":     Terminal,
+	"md":        Markdown,
+	"yml":       YAML,
+	"ndjson":    JSONLines,
+	"jsonlines": JSONLines,
+	"gosyntax":  GoSyntax,
+}
+
+// ParseOutputFormat converts format name like "terminal" ("table"), "markdown" ("md"), "json", "yaml" ("yml"), "jsonl" ("ndjson"), "toml", "go" ("gosyntax"), "tree", "interactive"
+// or name of [RegisterFormat] into [OutputFormat].
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	name = normalizeFormatName(name)
+	if f, ok := formatAliases[name]; ok {
+		return f, nil
+	}
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	for i, f := range formats {
+		if f.name == name {
+			return OutputFormat(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+}
+
+// String returns registered name of the format.
+func (f OutputFormat) String() string {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	if f >= 0 && int(f) < len(formats) {
+		return formats[f].name
+	}
+	return fmt.Sprintf("OutputFormat(%d)", int(f))
+}
+
+// Set implements flag.Value.
+func (f *OutputFormat) Set(name string) error {
+	v, err := ParseOutputFormat(name)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f OutputFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *OutputFormat) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
 }
 
 // FormatData is the simplest API.
@@ -38,6 +123,23 @@ func FormatData(data any, o ...Opt) error {
 	return FormatDataTo(data, colorable.NewColorableStdout(), o...)
 }
 
+// FormatDataTo is variation of [FormatData]. You can specify output destination.
+//
+// If out is terminal, it uses escape sequence to dump colorized output.
+// NO_COLOR, FORCE_COLOR, CLICOLOR, CLICOLOR_FORCE environment variables and [Opt.Color] override it.
+// If [Opt.Formatter] is not specified, it is decided by COLORTERM and TERM environment variables.
+// Output taller than the terminal is sent to $PAGER (default: "less -RS"). [Opt.Pager] overrides it.
+func FormatDataTo(data any, out io.Writer, o ...Opt) error {
+	// style is resolved with out, because pager receives output via buffer
+	ctx, err := detectRenderContext(out, o)
+	if err != nil {
+		return err
+	}
+	return withPager(out, ctx.Opt.pager(data), func(w io.Writer) error {
+		return render(data, w, ctx)
+	})
+}
+
 func normalizeOpt(o []Opt) Opt {
 	var result Opt
 	if len(o) > 0 {
@@ -52,63 +154,62 @@ func normalizeOpt(o []Opt) Opt {
 	if result.Style == "" {
 		result.Style = "monokai"
 	}
+	if result.TreeMaxItems == 0 {
+		result.TreeMaxItems = 20
+	}
+	if result.DocumentDepth == 0 {
+		result.DocumentDepth = 3
+	}
+	if result.MissingMarker == "" {
+		result.MissingMarker = "—"
+	}
 	return result
 }
 
 // FormatDataWithColor is [FormatDataTo]'s variation that always uses escape sequence to dump colorized output.
 func FormatDataWithColor(data any, out io.Writer, o ...Opt) error {
-	opt := normalizeOpt(o)
-	if opt.OutputFormat == Terminal || opt.OutputFormat == Markdown {
-		if cells, ok := canBeTable(data); ok {
-			cr := newColorTextRenderer(opt.Style, opt.Formatter)
-			if opt.OutputFormat == Terminal {
-				renderSliceAsTerminalTable(cells, cr, opt.EastAsianAmbiguousAsWide, out)
-			} else {
-				renderSliceAsMarkdownTable(cells, cr, opt.EastAsianAmbiguousAsWide, out)
-			}
-			return nil
-		} else {
-			opt.OutputFormat = YAML
-		}
-	}
-	if opt.OutputFormat == YAML {
-		var b bytes.Buffer
-		e := yaml.NewEncoder(&b)
-		e.SetIndent(opt.Indent)
-		e.Encode(data)
-		return quick.Highlight(out, b.String(), "yaml", opt.Formatter, opt.Style)
-	} else /* JSON */ {
-		var b bytes.Buffer
-		e := json.NewEncoder(&b)
-		e.SetIndent("", strings.Repeat(" ", opt.Indent))
-		e.Encode(data)
-		return quick.Highlight(out, b.String(), "json", opt.Formatter, opt.Style)
+	ctx, err := newRenderContext(out, o, true)
+	if err != nil {
+		return err
 	}
+	return render(data, out, ctx)
 }
 
 // FormatDataWithColor is [FormatDataTo]'s variation that always doesn't use escape sequence.
 func FormatDataWithoutColor(data any, out io.Writer, o ...Opt) error {
-	opt := normalizeOpt(o)
-	if opt.OutputFormat == Terminal || opt.OutputFormat == Markdown {
-		if cells, ok := canBeTable(data); ok {
-			cr := newPlainTextTableRenderer()
-			if opt.OutputFormat == Terminal {
-				renderSliceAsTerminalTable(cells, cr, opt.EastAsianAmbiguousAsWide, out)
-			} else {
-				renderSliceAsMarkdownTable(cells, cr, opt.EastAsianAmbiguousAsWide, out)
-			}
-			return nil
-		} else {
-			opt.OutputFormat = YAML
-		}
-	}
-	if opt.OutputFormat == YAML {
-		e := yaml.NewEncoder(out)
-		e.SetIndent(opt.Indent)
-		return e.Encode(data)
+	ctx, _ := newRenderContext(out, o, false)
+	return render(data, out, ctx)
+}
+
+// encodeData encodes data in YAML or JSON. Values that can't be encoded because of their types cause ErrUnsupportedType.
+func encodeData(data any, format OutputFormat, indent int) (string, error) {
+	var b bytes.Buffer
+	var err error
+	if format == YAML {
+		err = encodeYAML(&b, data, indent)
 	} else /* JSON */ {
-		e := json.NewEncoder(out)
-		e.SetIndent("", strings.Repeat(" ", opt.Indent))
-		return e.Encode(data)
+		e := json.NewEncoder(&b)
+		e.SetIndent("", strings.Repeat(" ", indent))
+		err = e.Encode(data)
+	}
+	var typeErr *json.UnsupportedTypeError
+	if errors.As(err, &typeErr) {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedType, err)
 	}
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// encodeYAML is same as yaml.Encoder.Encode but it converts panic for unsupported types (e.g. channels) into ErrUnsupportedType.
+func encodeYAML(out io.Writer, data any, indent int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = yamlPanicError(r)
+		}
+	}()
+	e := yaml.NewEncoder(out)
+	e.SetIndent(indent)
+	return e.Encode(data)
 }
@@ -9,14 +9,10 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
-// FormatDataTo is variation of [FormatData]. You can specify output destination.
-//
-// If out is terminal, it uses escape sequence to dump colorized output.
-func FormatDataTo(data any, out io.Writer, o ...Opt) error {
+// isTerminal returns true if out is terminal that accepts escape sequence.
+func isTerminal(out io.Writer) bool {
 	if fo, ok := out.(*os.File); ok {
-		if terminal.IsTerminal(int(fo.Fd())) {
-			return FormatDataWithColor(data, out, o...)
-		}
+		return terminal.IsTerminal(int(fo.Fd()))
 	}
-	return FormatDataWithoutColor(data, out, o...)
+	return false
 }
@@ -135,7 +135,7 @@ func TestFormatData(t *testing.T) {
 				`),
 		},
 		{
-			name: "Markdown: table ng data -> fallback to YAML",
+			name: "Markdown: table ng data -> fallback to YAML in code block",
 			args: args{
 				data: []string{
 					"AAAA",
@@ -146,12 +146,160 @@ func TestFormatData(t *testing.T) {
 					OutputFormat: Markdown,
 				},
 			},
+			wantOut: "```yaml\n- AAAA\n- BBB\n- CCCC\n```\n",
+		},
+		{
+			name: "Terminal: table ng data -> fallback to JSON",
+			args: args{
+				data: []string{"AAAA", "BBB"},
+				opt: Opt{
+					Fallback: FallbackJSON,
+				},
+			},
 			wantOut: trimIndent(`
-                   - AAAA
-                   - BBB
-                   - CCCC
+                   [
+                     "AAAA",
+                     "BBB"
+                   ]
 `),
 		},
+		{
+			name: "Markdown: table ng data -> fallback to tree",
+			args: args{
+				data: map[string]any{"a": 1},
+				opt: Opt{
+					OutputFormat: Markdown,
+					Fallback:     FallbackTree,
+				},
+			},
+			wantOut: "```text\n. {1}\n└── a: 1\n```\n",
+		},
+		{
+			name: "Terminal: row number",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+				opt: Opt{
+					RowNumber:      true,
+					RowNumberStart: intPtr(9),
+				},
+			},
+			wantOut: trimIndent(`
+				┌────┬───┬───┐
+				│  # │ a │ b │
+				╞════╪═══╪═══╡
+				│  9 │ x │ 1 │
+				├────┼───┼───┤
+				│ 10 │ y │ 2 │
+				└────┴───┴───┘
+				`),
+		},
+		{
+			name: "Markdown: row number",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+				opt: Opt{
+					OutputFormat: Markdown,
+					RowNumber:    true,
+				},
+			},
+			wantOut: trimIndent(`
+				| # | a | b |
+				|--:|---|---|
+				| 1 | x | 1 |
+				| 2 | y | 2 |
+				`),
+		},
+		{
+			name: "Markdown: row number from 0",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+				opt: Opt{
+					OutputFormat:   Markdown,
+					RowNumber:      true,
+					RowNumberStart: intPtr(0),
+				},
+			},
+			wantOut: trimIndent(`
+				| # | a | b |
+				|--:|---|---|
+				| 0 | x | 1 |
+				| 1 | y | 2 |
+				`),
+		},
+		{
+			name: "Terminal: style rule marker",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 600}},
+				opt: Opt{
+					StyleRules: []StyleRule{
+						{Column: "a", Value: "x", Style: "bold #ansired", Marker: "!"},
+						{Column: "b", Operator: ">", Value: 500, Style: "#ansiyellow"},
+					},
+					ShowRuleMarker: true,
+				},
+			},
+			wantOut: trimIndent(`
+				┌────┬──────┐
+				│ a  │ b    │
+				╞════╪══════╡
+				│ x! │ 1    │
+				├────┼──────┤
+				│ y  │ 600* │
+				└────┴──────┘
+				`),
+		},
+		{
+			name: "Terminal: select columns",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+				opt: Opt{
+					Columns: []string{"b", "a"},
+				},
+			},
+			wantOut: trimIndent(`
+				┌───┬───┐
+				│ b │ a │
+				╞═══╪═══╡
+				│ 1 │ x │
+				├───┼───┤
+				│ 2 │ y │
+				└───┴───┘
+				`),
+		},
+		{
+			name: "Terminal: missing and null markers",
+			args: args{
+				data: []map[string]any{{"a": "x", "b": nil}, {"a": ""}},
+				opt: Opt{
+					NullMarker: "null",
+				},
+			},
+			wantOut: trimIndent(`
+				┌───┬──────┐
+				│ a │ b    │
+				╞═══╪══════╡
+				│ x │ null │
+				├───┼──────┤
+				│   │ —    │
+				└───┴──────┘
+				`),
+		},
+		{
+			name: "Markdown: custom missing marker",
+			args: args{
+				data: []map[string]any{{"a": "x"}, {"b": 1}},
+				opt: Opt{
+					OutputFormat:  Markdown,
+					MissingMarker: "<none>",
+				},
+			},
+			wantOut: trimIndent(`
+				| a      | b      |
+				|--------|--------|
+				| x      | <none> |
+				| <none> | 1      |
+				`),
+		},
 		{
 			name: "YAML: table ok data",
 			args: args{
@@ -223,3 +371,7 @@ func TestFormatData(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(v int) *int {
+	return &v
+}
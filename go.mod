@@ -3,8 +3,10 @@ module github.com/shibukawa/formatdata-go
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/alecthomas/chroma/v2 v2.2.0
 	github.com/mattn/go-colorable v0.1.13
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/shibukawa/stringwidth v0.2.0
 	github.com/stretchr/testify v1.8.0
 	golang.org/x/crypto v0.0.0-20221005025214-4161e89ecf1b
@@ -16,7 +18,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dlclark/regexp2 v1.4.0 // indirect
 	github.com/mattn/go-isatty v0.0.16 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sys v0.0.0-20220811171246-fbc7d0a398ab // indirect
 	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1 // indirect
 	golang.org/x/text v0.3.7 // indirect
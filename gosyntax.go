@@ -0,0 +1,298 @@
+package formatdata
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// goSyntaxRenderer writes data as Go's composite literal like %#v, but it is indented and maps are sorted.
+type goSyntaxRenderer struct{}
+
+func (r goSyntaxRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (goSyntaxRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	src, err := encodeGoSyntax(data)
+	if err != nil {
+		return err
+	}
+	return ctx.Highlight(out, src, "go")
+}
+
+const goSyntaxPrefix = "package p\n\nvar v = "
+
+func encodeGoSyntax(data any) (string, error) {
+	p := goSyntaxPrinter{visited: map[uintptr]bool{}}
+	p.value(reflect.ValueOf(data), false)
+	if p.err != nil {
+		return "", p.err
+	}
+	// gofmt adjusts indent and alignment
+	src, err := format.Source([]byte(goSyntaxPrefix + p.b.String() + "\n"))
+	if err != nil {
+		return p.b.String() + "\n", nil
+	}
+	return strings.TrimPrefix(string(src), goSyntaxPrefix), nil
+}
+
+type goSyntaxPrinter struct {
+	b       strings.Builder
+	visited map[uintptr]bool
+	err     error
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// value writes v. If typed is true, the type is obvious from context (e.g. element of typed slice)
+// so type conversion of basic values and type name of composite literals are omitted.
+func (p *goSyntaxPrinter) value(v reflect.Value, typed bool) {
+	if !v.IsValid() {
+		p.b.WriteString("nil")
+		return
+	}
+	t := v.Type()
+	if t == timeType {
+		p.time(v)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			p.b.WriteString("nil")
+		} else {
+			p.value(v.Elem(), false)
+		}
+	case reflect.Bool:
+		p.basic(t, strconv.FormatBool(v.Bool()), typed, "bool")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.basic(t, strconv.FormatInt(v.Int(), 10), typed, "int")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.basic(t, strconv.FormatUint(v.Uint(), 10), typed, "")
+	case reflect.Float32, reflect.Float64:
+		p.basic(t, formatGoFloat(v.Float()), typed, "float64")
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		p.basic(t, fmt.Sprintf("complex(%s, %s)", formatGoFloat(real(c)), formatGoFloat(imag(c))), typed, "complex128")
+	case reflect.String:
+		p.basic(t, strconv.Quote(v.String()), typed, "string")
+	case reflect.Pointer:
+		p.pointer(v, typed)
+	case reflect.Slice:
+		if v.IsNil() {
+			p.nilValue(t, typed)
+			return
+		}
+		p.list(v, typed)
+	case reflect.Array:
+		p.list(v, typed)
+	case reflect.Map:
+		if v.IsNil() {
+			p.nilValue(t, typed)
+			return
+		}
+		p.mapValue(v, typed)
+	case reflect.Struct:
+		p.structValue(v, typed)
+	default:
+		if p.err == nil {
+			p.err = fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+		}
+		p.b.WriteString("nil")
+	}
+}
+
+// basic writes basic value. defaultType is a type of untyped constant that doesn't need conversion.
+func (p *goSyntaxPrinter) basic(t reflect.Type, literal string, typed bool, defaultType string) {
+	if typed || t.String() == defaultType {
+		p.b.WriteString(literal)
+		return
+	}
+	p.b.WriteString(t.String())
+	p.b.WriteString("(")
+	p.b.WriteString(literal)
+	p.b.WriteString(")")
+}
+
+func (p *goSyntaxPrinter) nilValue(t reflect.Type, typed bool) {
+	if typed {
+		p.b.WriteString("nil")
+	} else {
+		fmt.Fprintf(&p.b, "(%s)(nil)", t)
+	}
+}
+
+func (p *goSyntaxPrinter) typeName(t reflect.Type, typed bool) {
+	if !typed {
+		p.b.WriteString(t.String())
+	}
+}
+
+func (p *goSyntaxPrinter) pointer(v reflect.Value, typed bool) {
+	if v.IsNil() {
+		p.nilValue(v.Type(), typed)
+		return
+	}
+	if p.visited[v.Pointer()] {
+		p.b.WriteString("nil /* cycle */")
+		return
+	}
+	p.visited[v.Pointer()] = true
+	defer delete(p.visited, v.Pointer())
+
+	e := v.Elem()
+	switch e.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if e.Type() != timeType {
+			// &T{...}. "&T" can be omitted in typed context
+			if !typed {
+				p.b.WriteString("&")
+			}
+			p.value(e, typed)
+			return
+		}
+	}
+	// Pointer to non-composite value can't be written as literal
+	fmt.Fprintf(&p.b, "func() %s { v := ", v.Type())
+	p.value(e, false)
+	p.b.WriteString("; return &v }()")
+}
+
+func (p *goSyntaxPrinter) list(v reflect.Value, typed bool) {
+	p.typeName(v.Type(), typed)
+	elemTyped := v.Type().Elem().Kind() != reflect.Interface
+	if v.Len() == 0 {
+		p.b.WriteString("{}")
+		return
+	}
+	p.b.WriteString("{\n")
+	for i := 0; i < v.Len(); i++ {
+		p.value(v.Index(i), elemTyped)
+		p.b.WriteString(",\n")
+	}
+	p.b.WriteString("}")
+}
+
+func (p *goSyntaxPrinter) mapValue(v reflect.Value, typed bool) {
+	p.typeName(v.Type(), typed)
+	keys := v.MapKeys()
+	if len(keys) == 0 {
+		p.b.WriteString("{}")
+		return
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessValue(keys[i], keys[j])
+	})
+	keyTyped := v.Type().Key().Kind() != reflect.Interface
+	elemTyped := v.Type().Elem().Kind() != reflect.Interface
+	p.b.WriteString("{\n")
+	for _, k := range keys {
+		p.value(k, keyTyped)
+		p.b.WriteString(": ")
+		p.value(v.MapIndex(k), elemTyped)
+		p.b.WriteString(",\n")
+	}
+	p.b.WriteString("}")
+}
+
+func (p *goSyntaxPrinter) structValue(v reflect.Value, typed bool) {
+	p.typeName(v.Type(), typed)
+	t := v.Type()
+	var fields []int
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			fields = append(fields, i)
+		}
+	}
+	if len(fields) == 0 {
+		p.b.WriteString("{}")
+		return
+	}
+	p.b.WriteString("{\n")
+	for _, i := range fields {
+		p.b.WriteString(t.Field(i).Name)
+		p.b.WriteString(": ")
+		// unlike slices and maps, struct fields can't omit type name of composite literals
+		switch t.Field(i).Type.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+			p.value(v.Field(i), false)
+		default:
+			p.value(v.Field(i), true)
+		}
+		p.b.WriteString(",\n")
+	}
+	p.b.WriteString("}")
+}
+
+func (p *goSyntaxPrinter) time(v reflect.Value) {
+	// read via exported methods because time.Time has only unexported fields
+	if !v.CanInterface() {
+		p.b.WriteString("time.Time{}")
+		return
+	}
+	tm := v.Interface().(time.Time)
+	if tm.IsZero() {
+		p.b.WriteString("time.Time{}")
+		return
+	}
+	var loc string
+	switch tm.Location() {
+	case time.UTC:
+		loc = "time.UTC"
+	case time.Local:
+		loc = "time.Local"
+	default:
+		name, offset := tm.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	fmt.Fprintf(&p.b, "time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), loc)
+}
+
+func formatGoFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// lessValue compares map keys. Numbers and strings are compared by value, others by string representation.
+func lessValue(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
@@ -0,0 +1,101 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoSyntax(t *testing.T) {
+	type node struct {
+		Name  string
+		Next  *node
+		Attrs map[string]any
+	}
+	type args struct {
+		data any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "slice of struct",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y"}},
+			},
+			wantOut: trimIndent(`
+				[]formatdata.SampleStruct{
+					{
+						A: "x",
+						B: 1,
+					},
+					{
+						A: "y",
+					},
+				}
+				`),
+		},
+		{
+			name: "pointer and sorted map",
+			args: args{
+				data: &node{Name: "a", Next: &node{Name: "b"}, Attrs: map[string]any{"z": int64(1), "a": 1.0, "m": nil}},
+			},
+			wantOut: trimIndent(`
+				&formatdata.node{
+					Name: "a",
+					Next: &formatdata.node{
+						Name: "b",
+					},
+					Attrs: map[string]interface{}{
+						"a": 1.0,
+						"m": nil,
+						"z": int64(1),
+					},
+				}
+				`),
+		},
+		{
+			name: "scalars",
+			args: args{
+				data: []any{"s", 1, uint8(2), true, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), []int(nil)},
+			},
+			wantOut: trimIndent(`
+				[]interface{}{
+					"s",
+					1,
+					uint8(2),
+					true,
+					time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+					([]int)(nil),
+				}
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			assert.NoError(t, FormatDataWithoutColor(tt.args.data, out, Opt{OutputFormat: GoSyntax}))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestGoSyntaxCycle(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+	out := &bytes.Buffer{}
+	assert.NoError(t, FormatDataWithoutColor(n, out, Opt{OutputFormat: GoSyntax}))
+	assert.Contains(t, out.String(), "Next: nil, /* cycle */")
+}
+
+func TestGoSyntaxUnsupported(t *testing.T) {
+	err := FormatDataWithoutColor([]any{make(chan int)}, &bytes.Buffer{}, Opt{OutputFormat: GoSyntax})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
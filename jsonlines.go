@@ -0,0 +1,130 @@
+package formatdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// jsonLinesRenderer writes one compact JSON value per slice element.
+// If Opt.Columns is specified and the slice is tabular, each line is an object of the selected columns as same as tables.
+// Channel is also accepted as streaming input. Each line is written as soon as the element is received.
+type jsonLinesRenderer struct{}
+
+func (r jsonLinesRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (jsonLinesRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	write := func(line string) error {
+		return ctx.Highlight(out, line, "json")
+	}
+	writeLine := func(e any) error {
+		line, err := encodeJSONLine(e, ctx.Opt.Columns)
+		if err != nil {
+			return err
+		}
+		return write(line)
+	}
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if cells, ok := canBeTable(data); ok && len(ctx.Opt.Columns) > 0 {
+			return renderTableAsJSONLines(selectColumns(cells, ctx.Opt.Columns), write)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := writeLine(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return fmt.Errorf("%w: send-only channel", ErrUnsupportedType)
+		}
+		for {
+			e, ok := v.Recv()
+			if !ok {
+				return nil
+			}
+			if err := writeLine(e.Interface()); err != nil {
+				return err
+			}
+		}
+	default:
+		return writeLine(data)
+	}
+}
+
+// renderTableAsJSONLines writes each body row as object. Columns are selected in the same way as tables,
+// so key names are same as table headers. Missing cells are not written.
+func renderTableAsJSONLines(table [][]any, write func(line string) error) error {
+	if len(table) == 0 {
+		return nil
+	}
+	for _, row := range table[1:] {
+		obj := map[string]any{}
+		var columns []string
+		for c, h := range table[0] {
+			if c >= len(row) {
+				break
+			}
+			if _, ok := row[c].(missingCell); ok {
+				continue
+			}
+			name := fmt.Sprint(h)
+			obj[name] = row[c]
+			columns = append(columns, name)
+		}
+		line, err := encodeJSONLine(obj, columns)
+		if err != nil {
+			return err
+		}
+		if err := write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeJSONLine encodes single element. If columns are specified and the element is an object,
+// only the columns are written in the specified order.
+func encodeJSONLine(e any, columns []string) (string, error) {
+	src, err := json.Marshal(e)
+	var typeErr *json.UnsupportedTypeError
+	if errors.As(err, &typeErr) {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(columns) == 0 || !bytes.HasPrefix(src, []byte("{")) {
+		return string(src) + "\n", nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(src, &obj); err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	first := true
+	for _, c := range columns {
+		value, ok := obj[c]
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		key, _ := json.Marshal(c)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
@@ -0,0 +1,101 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type untaggedUser struct {
+	Name string
+	Age  int
+	City string
+}
+
+func TestJSONLines(t *testing.T) {
+	stream := make(chan SampleStruct, 2)
+	stream <- SampleStruct{A: "x", B: 1}
+	stream <- SampleStruct{A: "y", B: 2}
+	close(stream)
+
+	type args struct {
+		data any
+		opt  Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "slice",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+			},
+			wantOut: trimIndent(`
+				{"a":"x","b":1}
+				{"a":"y","b":2}
+				`),
+		},
+		{
+			name: "columns",
+			args: args{
+				data: []map[string]any{{"a": "x", "b": 1, "c": true}, {"a": "y", "b": 2.5}},
+				opt:  Opt{Columns: []string{"c", "a"}},
+			},
+			wantOut: trimIndent(`
+				{"c":true,"a":"x"}
+				{"a":"y"}
+				`),
+		},
+		{
+			name: "columns of untagged struct use same names as table",
+			args: args{
+				data: []untaggedUser{{Name: "alice", Age: 30, City: "Tokyo"}},
+				opt:  Opt{Columns: []string{"name", "age"}},
+			},
+			wantOut: trimIndent(`
+				{"name":"alice","age":30}
+				`),
+		},
+		{
+			name: "columns of slice with header row",
+			args: args{
+				data: [][]any{{"name", "age", "city"}, {"alice", 30, "Tokyo"}, {"bob", nil, "Osaka"}},
+				opt:  Opt{Columns: []string{"city", "age"}},
+			},
+			wantOut: trimIndent(`
+				{"city":"Tokyo","age":30}
+				{"city":"Osaka","age":null}
+				`),
+		},
+		{
+			name: "channel",
+			args: args{
+				data: stream,
+			},
+			wantOut: trimIndent(`
+				{"a":"x","b":1}
+				{"a":"y","b":2}
+				`),
+		},
+		{
+			name: "not slice",
+			args: args{
+				data: map[string]int{"a": 1},
+			},
+			wantOut: trimIndent(`
+				{"a":1}
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.args.opt.OutputFormat = JSONLines
+			assert.NoError(t, FormatDataWithoutColor(tt.args.data, out, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
@@ -6,41 +6,60 @@ import (
 	"github.com/shibukawa/stringwidth"
 )
 
-func renderSliceAsMarkdownTable(table [][]any, cr *tableRenderer, eastAsianAmbiguousAsWide bool, out io.Writer) {
-	maxWidths, renderCells := calcTableSize(table, cr, eastAsianAmbiguousAsWide)
-	repeat := func(r rune, length int) {
-		for i := 0; i < length; i++ {
-			io.WriteString(out, string(r))
-		}
-	}
+func renderSliceAsMarkdownTable(table [][]any, cr *tableRenderer, eastAsianAmbiguousAsWide bool, out io.Writer) error {
+	maxWidths, rightAligns, renderCells := calcTableSize(table, cr, eastAsianAmbiguousAsWide)
+	w := newTableWriter(out)
 	drawHorizontal := func() {
-		out.Write([]byte{'|'})
+		w.column = -1
+		w.WriteString(cr.lineStart(false))
+		w.WriteString("|")
 		for i, m := range maxWidths {
 			if i != 0 {
-				out.Write([]byte{'|'})
+				w.WriteString("|")
+			}
+			if rightAligns[i] {
+				w.Repeat("-", m+1)
+				w.WriteString(":")
+			} else {
+				w.Repeat("-", m+2)
 			}
-			repeat('-', m+2)
 		}
-		out.Write([]byte{'|', '\n'})
+		w.WriteString("|")
+		w.WriteString(cr.lineEnd)
+		w.WriteString("\n")
 	}
 
 	for r, row := range renderCells {
-		out.Write([]byte{'|'})
-		for i, w := range maxWidths {
+		w.row = r
+		w.column = -1
+		w.WriteString(cr.lineStart(r == 0))
+		w.WriteString("|")
+		for i, width := range maxWidths {
 			var c string
 			if i < len(row) {
 				c = row[i]
 			}
-			out.Write([]byte{' '})
-			io.WriteString(out, c)
-			repeat(' ', 1+w-stringwidth.Calc(c, stringwidth.Opt{
+			w.column = i
+			padding := width - stringwidth.Calc(c, stringwidth.Opt{
 				IsAmbiguousWide: eastAsianAmbiguousAsWide,
-			}))
-			out.Write([]byte{'|'})
+			})
+			if rightAligns[i] {
+				w.Repeat(" ", 1+padding)
+				w.WriteString(cr.restoreBackground(c))
+				w.WriteString(" ")
+			} else {
+				w.WriteString(" ")
+				w.WriteString(cr.restoreBackground(c))
+				w.Repeat(" ", 1+padding)
+			}
+			w.WriteString("|")
 		}
-		out.Write([]byte{'\n'})
+		w.column = -1
+		w.WriteString(cr.lineEnd)
+		w.WriteString("\n")
 		if r == 0 && len(renderCells) != 1 {
 			drawHorizontal()
 		}
 	}
+	return w.err
 }
@@ -0,0 +1,231 @@
+package formatdata
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// PagerMode controls whether [FormatDataTo] sends output to pager or not.
+type PagerMode int
+
+const (
+	PagerAuto   PagerMode = iota // Default. It uses pager if out is terminal and output is taller than the terminal.
+	PagerAlways                  // Always uses pager if it exists.
+	PagerNever                   // Never uses pager.
+)
+
+var pagerModeNames = []string{"auto", "always", "never"}
+
+// String returns "auto", "always" or "never".
+func (m PagerMode) String() string {
+	return enumName(pagerModeNames, int(m), "PagerMode")
+}
+
+// Set implements flag.Value. It accepts "auto", "always" and "never".
+func (m *PagerMode) Set(name string) error {
+	v, err := parseEnumName(pagerModeNames, name, "pager mode")
+	if err != nil {
+		return err
+	}
+	*m = PagerMode(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m PagerMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *PagerMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+const defaultPager = "less -RS"
+
+// pager returns pager mode. Interactive format has its own screen, and channel is a stream that may not end,
+// so pager is not used for them.
+func (o Opt) pager(data any) PagerMode {
+	if o.OutputFormat == Interactive || reflect.ValueOf(data).Kind() == reflect.Chan {
+		return PagerNever
+	}
+	return o.Pager
+}
+
+// withPager calls render and sends its output to pager ($PAGER or "less -RS") if mode requires.
+// In PagerAuto mode, output is buffered until it gets taller than the terminal. After that, the pager starts
+// and receives the rest of output as it arrives. If pager doesn't exist, output is written to out directly.
+func withPager(out io.Writer, mode PagerMode, render func(w io.Writer) error) error {
+	if mode == PagerNever || (mode == PagerAuto && !isTerminal(out)) {
+		return render(out)
+	}
+	// PagerAlways buffers only a few lines to find table header
+	threshold := pagerHeaderLookahead
+	if mode == PagerAuto {
+		height, ok := terminalHeight(out)
+		if !ok {
+			return render(out)
+		}
+		threshold = height
+	}
+	p := &pagerWriter{out: out, threshold: threshold}
+	err := render(p)
+	if closeErr := p.close(mode == PagerAlways && err == nil); err == nil || errors.Is(err, errPagerClosed) {
+		err = closeErr
+	}
+	return err
+}
+
+// pagerHeaderLookahead is line count that countHeaderLines needs to find table header.
+const pagerHeaderLookahead = 5
+
+// errPagerClosed is returned from pagerWriter when user quits the pager before output ends.
+var errPagerClosed = errors.New("pager is closed")
+
+// pagerWriter buffers output until it reaches threshold lines. Then it starts pager and streams output into it.
+type pagerWriter struct {
+	out       io.Writer
+	threshold int
+	buf       bytes.Buffer
+	cmd       *exec.Cmd
+	pipe      io.WriteCloser
+	direct    bool // pager doesn't exist, so output is written to out directly
+}
+
+func (p *pagerWriter) Write(b []byte) (int, error) {
+	switch {
+	case p.pipe != nil:
+		if n, err := p.pipe.Write(b); err != nil {
+			return n, errPagerClosed
+		}
+		return len(b), nil
+	case p.direct:
+		return p.out.Write(b)
+	}
+	p.buf.Write(b)
+	if bytes.Count(p.buf.Bytes(), []byte("\n")) < p.threshold {
+		return len(b), nil
+	}
+	if err := p.start(); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// start starts pager and sends buffered output to it.
+func (p *pagerWriter) start() error {
+	if cmd := pagerCommand(countHeaderLines(p.buf.String())); cmd != nil {
+		cmd.Stdout = p.out
+		cmd.Stderr = os.Stderr
+		if pipe, err := cmd.StdinPipe(); err == nil && cmd.Start() == nil {
+			p.cmd = cmd
+			p.pipe = pipe
+			_, err := p.pipe.Write(p.buf.Bytes())
+			p.buf.Reset()
+			if err != nil {
+				return errPagerClosed
+			}
+			return nil
+		}
+	}
+	p.direct = true
+	_, err := p.out.Write(p.buf.Bytes())
+	p.buf.Reset()
+	return err
+}
+
+// close waits for the pager. If the pager isn't started yet, buffered output is written to out
+// (or to the pager if force is true).
+func (p *pagerWriter) close(force bool) error {
+	if p.pipe == nil && !p.direct {
+		if !force {
+			_, err := p.out.Write(p.buf.Bytes())
+			return err
+		}
+		if err := p.start(); err != nil {
+			return err
+		}
+	}
+	if p.pipe == nil {
+		return nil
+	}
+	p.pipe.Close()
+	return p.cmd.Wait()
+}
+
+// screenHeight returns height of the terminal. If out is not terminal, $LINES is used.
+func screenHeight(out io.Writer) int {
+	if height, ok := terminalHeight(out); ok {
+		return height
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return 0
+}
+
+func terminalHeight(out io.Writer) (int, bool) {
+	if fo, ok := out.(*os.File); ok {
+		_, height, err := terminal.GetSize(int(fo.Fd()))
+		return height, err == nil && height > 0
+	}
+	return 0, false
+}
+
+// pagerCommand returns command of $PAGER. If the pager is less, headerLines are kept visible by --header option (less 600+).
+func pagerCommand(headerLines int) *exec.Cmd {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultPager
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 {
+		return nil
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return nil
+	}
+	if headerLines > 0 && filepath.Base(args[0]) == "less" {
+		if version, err := exec.Command(path, "--version").Output(); err == nil && parseLessVersion(string(version)) >= 600 {
+			args = append(args, "--header="+strconv.Itoa(headerLines))
+		}
+	}
+	return exec.Command(path, args[1:]...)
+}
+
+var lessVersionPattern = regexp.MustCompile(`^less (\d+)`)
+
+func parseLessVersion(output string) int {
+	m := lessVersionPattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.Atoi(m[1])
+	return v
+}
+
+var escapeSequencePattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// countHeaderLines returns line count of table header (top border, header row and separator).
+// It returns 0 if output is not table.
+func countHeaderLines(output string) int {
+	for i, line := range strings.SplitN(output, "\n", 5) {
+		line = escapeSequencePattern.ReplaceAllString(line, "")
+		if strings.HasPrefix(line, string(tableHorizonChars[tableHeader][0])) ||
+			(strings.HasPrefix(line, "|-") || strings.HasPrefix(line, "|:")) && strings.Trim(line, "|-: ") == "" {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,178 @@
+package formatdata
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerMode(t *testing.T) {
+	var m PagerMode
+	assert.NoError(t, m.Set("Always"))
+	assert.Equal(t, PagerAlways, m)
+	assert.Equal(t, "always", m.String())
+	assert.Error(t, m.Set("sometimes"))
+}
+
+func TestOpt_pager(t *testing.T) {
+	assert.Equal(t, PagerAlways, Opt{Pager: PagerAlways}.pager([]int{1}))
+	assert.Equal(t, PagerNever, Opt{Pager: PagerAlways}.pager(make(chan int)), "stream may not end")
+	assert.Equal(t, PagerNever, Opt{Pager: PagerAlways, OutputFormat: Interactive}.pager([]int{1}))
+}
+
+func TestWithPager(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("tr command is not available")
+	}
+	render := func(w io.Writer) error {
+		_, err := io.WriteString(w, "hello\n")
+		return err
+	}
+	tests := []struct {
+		name  string
+		mode  PagerMode
+		pager string
+		want  string
+	}{
+		{
+			name:  "always",
+			mode:  PagerAlways,
+			pager: "tr a-z A-Z",
+			want:  "HELLO\n",
+		},
+		{
+			name:  "auto doesn't use pager if out is not terminal",
+			mode:  PagerAuto,
+			pager: "tr a-z A-Z",
+			want:  "hello\n",
+		},
+		{
+			name:  "never",
+			mode:  PagerNever,
+			pager: "tr a-z A-Z",
+			want:  "hello\n",
+		},
+		{
+			name:  "pager doesn't exist",
+			mode:  PagerAlways,
+			pager: "formatdata-no-such-pager",
+			want:  "hello\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAGER", tt.pager)
+			var buf bytes.Buffer
+			assert.NoError(t, withPager(&buf, tt.mode, render))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+// syncBuffer is a bytes.Buffer that pager process can write to while test reads it.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func Test_pagerWriter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cat command is not available")
+	}
+	t.Setenv("PAGER", "cat")
+
+	t.Run("output shorter than threshold is written directly", func(t *testing.T) {
+		var out syncBuffer
+		p := &pagerWriter{out: &out, threshold: 3}
+		io.WriteString(p, "a\nb\n")
+		assert.Equal(t, "", out.String())
+		assert.NoError(t, p.close(false))
+		assert.Equal(t, "a\nb\n", out.String())
+		assert.Nil(t, p.cmd)
+	})
+
+	t.Run("pager receives output as it arrives after threshold", func(t *testing.T) {
+		var out syncBuffer
+		p := &pagerWriter{out: &out, threshold: 2}
+		io.WriteString(p, "a\n")
+		io.WriteString(p, "b\n")
+		assert.NotNil(t, p.cmd)
+		// rendering is not finished yet, but pager already shows first lines
+		assert.Eventually(t, func() bool {
+			return out.String() == "a\nb\n"
+		}, 5*time.Second, 10*time.Millisecond)
+		io.WriteString(p, "c\n")
+		assert.NoError(t, p.close(false))
+		assert.Equal(t, "a\nb\nc\n", out.String())
+	})
+
+	t.Run("quitting pager stops rendering without error", func(t *testing.T) {
+		t.Setenv("PAGER", "head -n 1")
+		var out syncBuffer
+		err := withPager(&out, PagerAlways, func(w io.Writer) error {
+			for {
+				if _, err := io.WriteString(w, "line\n"); err != nil {
+					return err
+				}
+			}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "line\n", out.String())
+	})
+}
+
+func Test_countHeaderLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{
+			name:   "terminal",
+			output: "┌───┐\n│ a │\n╞═══╡\n│ 1 │\n└───┘\n",
+			want:   3,
+		},
+		{
+			name:   "terminal with color",
+			output: "\033[1m┌───┐\033[0m\n\033[1m│\033[0m a \033[1m│\033[0m\n\033[1m╞═══╡\033[0m\n",
+			want:   3,
+		},
+		{
+			name:   "markdown",
+			output: "| # | a |\n|--:|---|\n| 1 | x |\n",
+			want:   2,
+		},
+		{
+			name:   "yaml",
+			output: "- a\n- b\n",
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, countHeaderLines(tt.output))
+		})
+	}
+}
+
+func Test_parseLessVersion(t *testing.T) {
+	assert.Equal(t, 608, parseLessVersion("less 608 (PCRE2 regular expressions)\nCopyright (C) 1984-2022  Mark Nudelman\n"))
+	assert.Equal(t, 0, parseLessVersion("BusyBox v1.36.1"))
+}
@@ -0,0 +1,53 @@
+package formatdata
+
+import "github.com/alecthomas/chroma/v2"
+
+// Palette maps roles of table elements to chroma's token types.
+// Colors of the tokens come from [Opt.Style].
+//
+// Zero fields of [Opt.Palette] are filled with [DefaultPalette].
+type Palette struct {
+	Header  chroma.TokenType // Header row
+	String  chroma.TokenType // String cell
+	Number  chroma.TokenType // Integer and float cell
+	Bool    chroma.TokenType // Bool cell
+	Null    chroma.TokenType // Null cell
+	Time    chroma.TokenType // time.Time cell
+	Border  chroma.TokenType // Border lines
+	Type    chroma.TokenType // Type and length annotation of Tree view
+	Caption chroma.TokenType // Caption of FormatTables
+	Missing chroma.TokenType // Cell whose key doesn't exist in the row
+}
+
+// DefaultPalette uses same token types as YAML lexer does.
+var DefaultPalette = Palette{
+	Header:  chroma.NameTag,
+	String:  chroma.LiteralString,
+	Number:  chroma.LiteralNumber,
+	Bool:    chroma.KeywordConstant,
+	Null:    chroma.KeywordConstant,
+	Time:    chroma.LiteralDate,
+	Border:  chroma.Punctuation,
+	Type:    chroma.KeywordType,
+	Caption: chroma.Comment,
+	Missing: chroma.Comment,
+}
+
+func (p Palette) withDefault() Palette {
+	fill := func(t *chroma.TokenType, d chroma.TokenType) {
+		if *t == 0 {
+			*t = d
+		}
+	}
+	fill(&p.Header, DefaultPalette.Header)
+	fill(&p.String, DefaultPalette.String)
+	fill(&p.Number, DefaultPalette.Number)
+	fill(&p.Bool, DefaultPalette.Bool)
+	fill(&p.Null, DefaultPalette.Null)
+	fill(&p.Time, DefaultPalette.Time)
+	fill(&p.Border, DefaultPalette.Border)
+	fill(&p.Type, DefaultPalette.Type)
+	fill(&p.Caption, DefaultPalette.Caption)
+	fill(&p.Missing, DefaultPalette.Missing)
+	return p
+}
@@ -0,0 +1,33 @@
+package formatdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPalette(t *testing.T) {
+	tr := newColorTextRenderer(styles.Get("monokai"), "terminal16m", Palette{}, false)
+	assert.NotEqual(t, tr.stringCell("1", false), tr.intCell(1, false), "string and number should be distinguishable")
+	assert.Equal(t, tr.styledCell("a", chroma.NameTag), tr.stringCell("a", true))
+	assert.Equal(t, tr.styledCell("2022-10-01T00:00:00Z", chroma.LiteralDate), tr.timeCell(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), false))
+
+	tr = newColorTextRenderer(styles.Get("monokai"), "terminal16m", Palette{String: chroma.GenericDeleted}, false)
+	assert.Equal(t, tr.styledCell("a", chroma.GenericDeleted), tr.stringCell("a", false))
+	assert.Equal(t, tr.styledCell("1", chroma.LiteralNumber), tr.intCell(1, false), "unspecified roles use default")
+}
+
+func TestMissingMarkerStyle(t *testing.T) {
+	cells, err := prepareTable([][]any{{"a", "b"}, {nil, missingCell{}}}, Opt{NullMarker: "null", MissingMarker: "—"})
+	assert.NoError(t, err)
+	tr := newColorTextRenderer(styles.Get("monokai"), "terminal16m", Palette{}, false)
+	// faint (SGR 2) and palette's color
+	assert.Equal(t, "\033[2m"+tr.styledCell("null", DefaultPalette.Null), formatCell(tr, cells[1][0], false))
+	assert.Equal(t, "\033[2m"+tr.styledCell("—", chroma.Comment), formatCell(tr, cells[1][1], false), "missing cells are dim")
+
+	plain := formatCell(plainTextTableRenderer, cells[1][1], false)
+	assert.Equal(t, "—", plain)
+}
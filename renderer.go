@@ -0,0 +1,217 @@
+package formatdata
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// Table is grid data that is converted from data. The first row is header.
+type Table = [][]any
+
+// RenderContext is passed to [Renderer]. It has normalized options and color information.
+type RenderContext struct {
+	Opt    Opt
+	Color  bool          // True if the renderer should use escape sequence
+	Style  *chroma.Style // Resolved style. It is nil if Color is false
+	Height int           // Height of the terminal. $LINES is used if output is not terminal. 0 if unknown
+}
+
+// Highlight writes source with syntax highlight by chroma's lexer if color is enabled. Otherwise, it writes source as is.
+func (c RenderContext) Highlight(out io.Writer, source, lexer string) error {
+	if !c.Color {
+		_, err := io.WriteString(out, source)
+		return err
+	}
+	return highlight(out, source, lexer, c.Opt.Formatter, c.Style)
+}
+
+func (c RenderContext) tableRenderer() *tableRenderer {
+	if c.Color {
+		return newColorTextRenderer(c.Style, c.Opt.Formatter, c.Opt.Palette, c.Opt.FillBackground)
+	}
+	return newPlainTextTableRenderer()
+}
+
+// newRenderContext normalizes options and finds style if color is true.
+// It is shared by WithColor and WithoutColor variations of public functions.
+func newRenderContext(out io.Writer, o []Opt, color bool) (RenderContext, error) {
+	// out may be replaced with pager's buffer later, so its height is kept here
+	ctx := RenderContext{Opt: normalizeOpt(o), Height: screenHeight(out)}
+	if !color {
+		return ctx, nil
+	}
+	style, err := findStyle(ctx.Opt, out)
+	if err != nil {
+		return RenderContext{}, err
+	}
+	ctx.Color = true
+	ctx.Style = style
+	return ctx, nil
+}
+
+// detectRenderContext decides color output by out, environment variables and [Opt.Color]
+// like [FormatDataTo], and fills formatter detected from environment variables.
+func detectRenderContext(out io.Writer, o []Opt) (RenderContext, error) {
+	var opt Opt
+	if len(o) > 0 {
+		opt = o[0]
+	}
+	color := useColor(out, opt.Color)
+	if color && opt.Formatter == "" {
+		opt.Formatter = detectFormatter()
+	}
+	return newRenderContext(out, []Opt{opt}, color)
+}
+
+// Renderer renders table data.
+//
+// If the renderer also implements [ValueRenderer], RenderValue is used for any data instead.
+// Otherwise, Render is called only when data can be represented as table. Other data is rendered by [Opt.Fallback].
+type Renderer interface {
+	Render(table Table, out io.Writer, ctx RenderContext) error
+}
+
+// ValueRenderer renders any data as is (e.g. JSON, YAML).
+type ValueRenderer interface {
+	RenderValue(data any, out io.Writer, ctx RenderContext) error
+}
+
+type registeredFormat struct {
+	name     string
+	renderer Renderer
+}
+
+var (
+	formatLock sync.RWMutex
+	// index is OutputFormat
+	formats = []registeredFormat{
+		{name: "terminal", renderer: terminalRenderer{}},
+		{name: "markdown", renderer: markdownRenderer{}},
+		{name: "json", renderer: jsonRenderer{}},
+		{name: "yaml", renderer: yamlRenderer{}},
+		{name: "jsonl", renderer: jsonLinesRenderer{}},
+		{name: "toml", renderer: tomlRenderer{}},
+		{name: "go", renderer: goSyntaxRenderer{}},
+		{name: "tree", renderer: treeRenderer{}},
+		{name: "interactive", renderer: interactiveRenderer{}},
+	}
+)
+
+// RegisterFormat adds new output format and returns its [OutputFormat] value.
+// Names are case-insensitive like [ParseOutputFormat]. If the name is already registered, the renderer is replaced.
+func RegisterFormat(name string, r Renderer) OutputFormat {
+	name = normalizeFormatName(name)
+	formatLock.Lock()
+	defer formatLock.Unlock()
+	for i, f := range formats {
+		if f.name == name {
+			formats[i].renderer = r
+			return OutputFormat(i)
+		}
+	}
+	formats = append(formats, registeredFormat{name: name, renderer: r})
+	return OutputFormat(len(formats) - 1)
+}
+
+// normalizeFormatName converts format name into lower case to match it case-insensitively.
+func normalizeFormatName(name string) string {
+	return strings.ToLower(name)
+}
+
+func findRenderer(f OutputFormat) (Renderer, error) {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	if f < 0 || int(f) >= len(formats) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownFormat, f)
+	}
+	return formats[f].renderer, nil
+}
+
+func render(data any, out io.Writer, ctx RenderContext) error {
+	r, err := findRenderer(ctx.Opt.OutputFormat)
+	if err != nil {
+		return err
+	}
+	if vr, ok := r.(ValueRenderer); ok {
+		return vr.RenderValue(data, out, ctx)
+	}
+	if cells, ok := canBeTable(data); ok {
+		return r.Render(exportTable(r, selectColumns(cells, ctx.Opt.Columns), ctx.Opt), out, ctx)
+	}
+	return renderFallback(data, out, ctx, r)
+}
+
+type terminalRenderer struct{}
+
+func (terminalRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	cells, err := prepareTable(table, ctx.Opt)
+	if err != nil {
+		return err
+	}
+	tr := ctx.tableRenderer()
+	chunks, err := splitTableColumns(cells, tr, ctx.Opt)
+	if err != nil {
+		return err
+	}
+	for i, chunk := range chunks {
+		opt := ctx.Opt
+		if i > 0 {
+			if _, err := io.WriteString(out, "\n"); err != nil {
+				return err
+			}
+			opt.Title = ""
+		}
+		if i < len(chunks)-1 {
+			opt.ShowRowCount = false
+		}
+		if opt.RepeatHeader == RepeatHeaderByScreen {
+			opt.RepeatHeader = repeatHeaderByHeight(ctx.Height)
+		}
+		if err := renderSliceAsTerminalTable(chunk, tr, opt, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	cells, err := prepareTable(table, ctx.Opt)
+	if err != nil {
+		return err
+	}
+	return renderSliceAsMarkdownTable(cells, ctx.tableRenderer(), ctx.Opt.EastAsianAmbiguousAsWide, out)
+}
+
+type jsonRenderer struct{}
+
+func (r jsonRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (jsonRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	src, err := encodeData(data, JSON, ctx.Opt.Indent)
+	if err != nil {
+		return err
+	}
+	return ctx.Highlight(out, src, "json")
+}
+
+type yamlRenderer struct{}
+
+func (r yamlRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (yamlRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	src, err := encodeData(data, YAML, ctx.Opt.Indent)
+	if err != nil {
+		return err
+	}
+	return ctx.Highlight(out, src, "yaml")
+}
@@ -0,0 +1,80 @@
+package formatdata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCSVRenderer struct{}
+
+func (testCSVRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	for _, row := range table {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			cells[i] = fmt.Sprint(c)
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(cells, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRegisterFormat(t *testing.T) {
+	csv := RegisterFormat("test-csv", testCSVRenderer{})
+	assert.Equal(t, csv, RegisterFormat("test-csv", testCSVRenderer{}), "same name returns same format")
+
+	var out bytes.Buffer
+	err := FormatDataWithoutColor([]SampleStruct{{A: "x", B: 1}}, &out, Opt{OutputFormat: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b\nx,1\n", out.String())
+
+	out.Reset()
+	err = FormatDataWithoutColor(map[string]int{"a": 1}, &out, Opt{OutputFormat: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, "a: 1\n", out.String(), "not table data falls back to YAML")
+
+	out.Reset()
+	err = FormatDataWithoutColor([]map[string]any{{"a": "x", "b": nil}, {"a": "y"}}, &out, Opt{OutputFormat: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b\nx,<nil>\ny,<nil>\n", out.String(), "missing cells are passed as nil")
+
+	out.Reset()
+	err = FormatDataWithoutColor([]SampleStruct{{A: "x", B: 1}}, &out, Opt{OutputFormat: csv, RowNumber: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "#,a,b\n1,x,1\n", out.String(), "row numbers are added")
+
+	out.Reset()
+	err = FormatDiffWithoutColor([]SampleStruct{{A: "x", B: 1}}, []SampleStruct{{A: "x", B: 2}}, &out, Opt{OutputFormat: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, ",a,b\n-,x,1\n+,x,2\n", out.String(), "highlighted cells are unwrapped")
+
+	upper := RegisterFormat("TEST-Upper", testCSVRenderer{})
+	parsed, err := ParseOutputFormat("test-upper")
+	assert.NoError(t, err)
+	assert.Equal(t, upper, parsed, "names are case-insensitive")
+	parsed, err = ParseOutputFormat("TEST-UPPER")
+	assert.NoError(t, err)
+	assert.Equal(t, upper, parsed)
+
+	err = FormatDataWithoutColor(1, &out, Opt{OutputFormat: 1000})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func Test_exportTable(t *testing.T) {
+	table := [][]any{
+		{"", "a", "b"},
+		{styledCell{"-", chroma.GenericDeleted}, ruledCell{value: "x"}, styledCell{missingCell{}, chroma.GenericDeleted}},
+	}
+	assert.Equal(t, [][]any{
+		{"#", "", "a", "b"},
+		{0, "-", "x", nil},
+	}, exportTable(testCSVRenderer{}, table, Opt{RowNumber: true, RowNumberStart: intPtr(0)}))
+	assert.Equal(t, table, exportTable(terminalRenderer{}, table, Opt{RowNumber: true}), "built-in table renderers receive table as is")
+}
@@ -0,0 +1,154 @@
+package formatdata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// StyleRule is a condition to highlight table cells.
+//
+// This rule highlights latency cells that are greater than 500 in yellow:
+//
+//	formatdata.StyleRule{Column: "latency", Operator: ">", Value: 500, Style: "#yellow"}
+type StyleRule struct {
+	Column   string // Column name
+	Operator string // "==", "!=", ">", ">=", "<", "<=". Default: "=="
+	Value    any    // Value to compare with
+	Style    string // Chroma's style entry like "bold #ff0000", "#ansiyellow bg:#000000"
+	Marker   string // Suffix of the cell for plain text output (enabled by Opt.ShowRuleMarker). Default: "*"
+}
+
+// ruledCell is a cell that matches one of [StyleRule].
+type ruledCell struct {
+	value  any
+	entry  chroma.StyleEntry
+	marker string
+}
+
+// match compares the cell with the rule's value. Highlighted cells (e.g. diff) are compared by their original value.
+// Null and missing cells don't match ordering operators.
+func (r StyleRule) match(v any) bool {
+	v = unwrapCell(v)
+	switch r.Operator {
+	case "", "==":
+		return fmt.Sprint(v) == fmt.Sprint(r.Value)
+	case "!=":
+		return fmt.Sprint(v) != fmt.Sprint(r.Value)
+	}
+	if v == nil {
+		return false
+	}
+	var cmp int
+	a, aok := toFloat(v)
+	b, bok := toFloat(r.Value)
+	if aok && bok {
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	} else {
+		switch as, bs := fmt.Sprint(v), fmt.Sprint(r.Value); {
+		case as < bs:
+			cmp = -1
+		case as > bs:
+			cmp = 1
+		}
+	}
+	switch r.Operator {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// applyStyleRules wraps matched cells with ruledCell. If several rules match, first one is used.
+func applyStyleRules(table [][]any, rules []StyleRule, showMarker bool) ([][]any, error) {
+	if len(rules) == 0 || len(table) == 0 {
+		return table, nil
+	}
+	entries := make([]chroma.StyleEntry, len(rules))
+	for i, r := range rules {
+		switch r.Operator {
+		case "", "==", "!=", ">", ">=", "<", "<=":
+		default:
+			return nil, fmt.Errorf("invalid operator %q of style rule for column %q", r.Operator, r.Column)
+		}
+		entry, err := chroma.ParseStyleEntry(r.Style)
+		if err != nil {
+			return nil, fmt.Errorf("invalid style of style rule for column %q: %w", r.Column, err)
+		}
+		entries[i] = entry
+	}
+	columns := map[string]int{}
+	for c, h := range table[0] {
+		columns[fmt.Sprint(h)] = c
+	}
+	result := make([][]any, len(table))
+	result[0] = table[0]
+	for r, row := range table[1:] {
+		newRow := make([]any, len(row))
+		copy(newRow, row)
+		for i, rule := range rules {
+			c, ok := columns[rule.Column]
+			if !ok || c >= len(row) {
+				continue
+			}
+			if _, ok := newRow[c].(ruledCell); ok || !rule.match(row[c]) {
+				continue
+			}
+			var marker string
+			if showMarker {
+				marker = rule.Marker
+				if marker == "" {
+					marker = "*"
+				}
+			}
+			newRow[c] = ruledCell{value: row[c], entry: entries[i], marker: marker}
+		}
+		result[r+1] = newRow
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleRule_match(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  StyleRule
+		value any
+		want  bool
+	}{
+		{
+			name:  "default operator is ==",
+			rule:  StyleRule{Value: "FAILED"},
+			value: "FAILED",
+			want:  true,
+		},
+		{
+			name:  "!=",
+			rule:  StyleRule{Operator: "!=", Value: "FAILED"},
+			value: "FAILED",
+			want:  false,
+		},
+		{
+			name:  "compare number",
+			rule:  StyleRule{Operator: ">", Value: 500},
+			value: 501.5,
+			want:  true,
+		},
+		{
+			name:  "compare number in string",
+			rule:  StyleRule{Operator: "<=", Value: 500},
+			value: "1000",
+			want:  false,
+		},
+		{
+			name:  "compare string",
+			rule:  StyleRule{Operator: "<", Value: "b"},
+			value: "a",
+			want:  true,
+		},
+		{
+			name:  "null doesn't match ordering operator",
+			rule:  StyleRule{Operator: ">", Value: 500},
+			value: nil,
+			want:  false,
+		},
+		{
+			name:  "missing cell doesn't match ordering operator",
+			rule:  StyleRule{Operator: ">", Value: 500},
+			value: missingCell{},
+			want:  false,
+		},
+		{
+			name:  "null matches == nil",
+			rule:  StyleRule{Value: nil},
+			value: nil,
+			want:  true,
+		},
+		{
+			name:  "diff cell is compared by its value",
+			rule:  StyleRule{Operator: ">", Value: 500},
+			value: styledCell{2, chroma.GenericInserted},
+			want:  false,
+		},
+		{
+			name:  "diff cell with ==",
+			rule:  StyleRule{Value: 600},
+			value: styledCell{600, chroma.GenericInserted},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.match(tt.value))
+		})
+	}
+}
+
+func Test_applyStyleRules(t *testing.T) {
+	_, err := applyStyleRules([][]any{{"a"}, {1}}, []StyleRule{{Column: "a", Style: "red"}}, false)
+	assert.Error(t, err)
+
+	_, err = applyStyleRules([][]any{{"a"}, {1}}, []StyleRule{{Column: "a", Operator: "=~"}}, false)
+	assert.Error(t, err)
+}
+
+func TestStyleRule_nullMissingAndDiff(t *testing.T) {
+	rules := []StyleRule{{Column: "latency", Operator: ">", Value: 500, Style: "#ansiyellow"}}
+
+	var buf bytes.Buffer
+	data := []map[string]any{{"id": 1, "latency": nil}, {"id": 2}, {"id": 3, "latency": 600}}
+	assert.NoError(t, FormatDataWithoutColor(data, &buf, Opt{StyleRules: rules, ShowRuleMarker: true}))
+	assert.Equal(t, trimIndent(`
+		┌────┬─────────┐
+		│ id │ latency │
+		╞════╪═════════╡
+		│ 1  │         │
+		├────┼─────────┤
+		│ 2  │ —       │
+		├────┼─────────┤
+		│ 3  │ 600*    │
+		└────┴─────────┘
+		`), buf.String())
+
+	buf.Reset()
+	before := []map[string]any{{"id": 1, "latency": 1}}
+	after := []map[string]any{{"id": 1, "latency": 2}}
+	assert.NoError(t, FormatDiffWithoutColor(before, after, &buf, Opt{StyleRules: rules, ShowRuleMarker: true}))
+	assert.NotContains(t, buf.String(), "*", "changed cells are compared by their values")
+}
@@ -0,0 +1,128 @@
+package formatdata
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownStyle is returned when [Opt.Style] is not registered in chroma.
+var ErrUnknownStyle = errors.New("unknown style")
+
+var tokenTypes = func() map[string]chroma.TokenType {
+	result := map[string]chroma.TokenType{}
+	for t := range chroma.StandardTypes {
+		result[t.String()] = t
+	}
+	return result
+}()
+
+// findStyle returns [Opt.ChromaStyle] if specified. Otherwise, it searches [Opt.Style] in chroma's registry
+// or reads it as file if it has ".xml", ".yaml" or ".yml" extension.
+func findStyle(opt Opt, out io.Writer) (*chroma.Style, error) {
+	if opt.ChromaStyle != nil {
+		return opt.ChromaStyle, nil
+	}
+	name := resolveStyle(opt.Style, out)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xml", ".yaml", ".yml":
+		return LoadStyle(name)
+	}
+	if s, ok := styles.Registry[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownStyle, name)
+}
+
+// LoadStyle reads style file. Chroma's XML style format (".xml") and YAML palette (".yaml", ".yml") are supported.
+//
+// YAML palette is a map of token type names and style entries. "name" key is a style name:
+//
+//	name: brand
+//	Background: "#f8f8f2 bg:#1e1e2e"
+//	NameTag: "bold #ff8800"
+//	LiteralNumber: "#00aaff"
+func LoadStyle(path string) (*chroma.Style, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if strings.ToLower(filepath.Ext(path)) == ".xml" {
+		return ParseXMLStyle(f)
+	}
+	return ParseYAMLStyle(f, name)
+}
+
+// ParseXMLStyle parses chroma's XML style format like this:
+//
+//	<style name="brand">
+//	  <entry type="Background" style="#f8f8f2 bg:#1e1e2e"/>
+//	  <entry type="NameTag" style="bold #ff8800"/>
+//	</style>
+func ParseXMLStyle(r io.Reader) (*chroma.Style, error) {
+	var src struct {
+		Name    string `xml:"name,attr"`
+		Entries []struct {
+			Type  string `xml:"type,attr"`
+			Style string `xml:"style,attr"`
+		} `xml:"entry"`
+	}
+	if err := xml.NewDecoder(r).Decode(&src); err != nil {
+		return nil, err
+	}
+	entries := chroma.StyleEntries{}
+	for _, e := range src.Entries {
+		t, ok := tokenTypes[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown token type %q in style %q", e.Type, src.Name)
+		}
+		entries[t] = e.Style
+	}
+	return chroma.NewStyle(src.Name, entries)
+}
+
+// ParseYAMLStyle parses YAML palette. name is used if palette doesn't have "name" key.
+func ParseYAMLStyle(r io.Reader, name string) (*chroma.Style, error) {
+	var src map[string]string
+	if err := yaml.NewDecoder(r).Decode(&src); err != nil {
+		return nil, err
+	}
+	entries := chroma.StyleEntries{}
+	for k, v := range src {
+		if k == "name" {
+			name = v
+			continue
+		}
+		t, ok := tokenTypes[k]
+		if !ok {
+			return nil, fmt.Errorf("unknown token type %q in style %q", k, name)
+		}
+		entries[t] = v
+	}
+	return chroma.NewStyle(name, entries)
+}
+
+// highlight is same as quick.Highlight but it accepts style object.
+func highlight(out io.Writer, source, lexer, formatter string, style *chroma.Style) error {
+	l := lexers.Get(lexer)
+	if l == nil {
+		l = lexers.Fallback
+	}
+	it, err := chroma.Coalesce(l).Tokenise(nil, source)
+	if err != nil {
+		return err
+	}
+	return formatters.Get(formatter).Format(out, style, it)
+}
@@ -0,0 +1,66 @@
+package formatdata
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findStyle(t *testing.T) {
+	s, err := findStyle(Opt{Style: "monokai"}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "monokai", s.Name)
+
+	_, err = findStyle(Opt{Style: "not-exist"}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrUnknownStyle)
+
+	custom := chroma.MustNewStyle("custom", chroma.StyleEntries{chroma.NameTag: "#ff8800"})
+	s, err = findStyle(Opt{Style: "not-exist", ChromaStyle: custom}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Equal(t, custom, s)
+
+	path := filepath.Join(t.TempDir(), "brand.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`NameTag: "bold #ff8800"`), 0o644))
+	s, err = findStyle(Opt{Style: path}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "brand", s.Name)
+	assert.Equal(t, chroma.Yes, s.Get(chroma.NameTag).Bold)
+}
+
+func TestParseXMLStyle(t *testing.T) {
+	s, err := ParseXMLStyle(strings.NewReader(`
+		<style name="brand">
+		  <entry type="Background" style="#f8f8f2 bg:#1e1e2e"/>
+		  <entry type="NameTag" style="bold #ff8800"/>
+		</style>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "brand", s.Name)
+	assert.Equal(t, chroma.MustParseColour("#ff8800"), s.Get(chroma.NameTag).Colour)
+	assert.Equal(t, chroma.MustParseColour("#1e1e2e"), s.Get(chroma.Background).Background)
+
+	_, err = ParseXMLStyle(strings.NewReader(`<style name="brand"><entry type="Unknown" style="bold"/></style>`))
+	assert.Error(t, err)
+}
+
+func TestParseYAMLStyle(t *testing.T) {
+	s, err := ParseYAMLStyle(strings.NewReader(trimIndent(`
+		name: brand
+		LiteralNumber: "#00aaff"
+		`)), "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "brand", s.Name)
+	assert.Equal(t, chroma.MustParseColour("#00aaff"), s.Get(chroma.LiteralNumber).Colour)
+
+	_, err = ParseYAMLStyle(strings.NewReader(`LiteralNumber: "red"`), "default")
+	assert.Error(t, err)
+}
+
+func TestFormatDataWithColor_UnknownStyle(t *testing.T) {
+	err := FormatDataWithColor([][]string{{"a"}, {"b"}}, &bytes.Buffer{}, Opt{Style: "not-exist"})
+	assert.ErrorIs(t, err, ErrUnknownStyle)
+}
@@ -3,10 +3,11 @@ package formatdata
 import (
 	"bytes"
 	"fmt"
-	"io"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/chroma/v2"
 	"github.com/shibukawa/stringwidth"
@@ -19,22 +20,95 @@ type tableRenderer struct {
 	floatCell   func(a float64, title bool) string
 	stringCell  func(a string, title bool) string
 	boolCell    func(v bool, title bool) string
+	timeCell    func(v time.Time, title bool) string
+	nullCell    func(title bool) string
 	otherCell   func(v any, title bool) string
+	styledCell  func(s string, t chroma.TokenType) string
+	markerCell  func(s string, t chroma.TokenType) string
+	ruledCell   func(s string, c ruledCell) string
 	border      func(s string) string
 	borderStart string
 	borderEnd   string
+	lineStart   func(header bool) string
+	lineEnd     string
+	// restoreBackground paints current line's background after each reset sequence in pre-rendered cell.
+	restoreBackground func(s string) string
 }
 
-func renderTable(cr *tableRenderer, cells [][]any, o Opt, out io.Writer) {
-	if o.OutputFormat == Terminal {
-		renderSliceAsTerminalTable(cells, cr, o.EastAsianAmbiguousAsWide, out)
-	} else if o.OutputFormat == Markdown {
-		renderSliceAsMarkdownTable(cells, cr, o.EastAsianAmbiguousAsWide, out)
+// rowNumber is a cell type of index column. It is right-aligned.
+type rowNumber int
+
+// styledCell is a cell that is highlighted with specified token's color instead of its type's color.
+type styledCell struct {
+	value any
+	token chroma.TokenType
+}
+
+// missingCell is a cell whose key doesn't exist in the row. It is distinguished from nil (null value).
+type missingCell struct{}
+
+// markerCell is a null or missing cell shown as [Opt.NullMarker] or [Opt.MissingMarker].
+type markerCell struct {
+	text    string
+	token   chroma.TokenType
+	missing bool
+}
+
+var plainTextTableRenderer = newPlainTextTableRenderer()
+
+// selectColumns picks columns by header names in the specified order.
+// Columns that don't exist in the table are filled with missingCell.
+func selectColumns(table [][]any, columns []string) [][]any {
+	if len(columns) == 0 || len(table) == 0 {
+		return table
+	}
+	indexes := map[string]int{}
+	for i, h := range table[0] {
+		indexes[fmt.Sprint(h)] = i
+	}
+	result := make([][]any, len(table))
+	for r, row := range table {
+		newRow := make([]any, len(columns))
+		for c, name := range columns {
+			if r == 0 {
+				newRow[c] = name
+			} else if i, ok := indexes[name]; ok && i < len(row) {
+				newRow[c] = row[i]
+			} else {
+				newRow[c] = missingCell{}
+			}
+		}
+		result[r] = newRow
+	}
+	return result
+}
+
+// prepareTable checks cell types and applies options (style rules, markers, row numbers) to the table.
+func prepareTable(cells [][]any, o Opt) ([][]any, error) {
+	if err := checkCellTypes(cells); err != nil {
+		return nil, err
+	}
+	cells, err := applyStyleRules(cells, o.StyleRules, o.ShowRuleMarker)
+	if err != nil {
+		return nil, err
 	}
+	cells = applyMarkers(cells, o)
+	if o.RowNumber {
+		cells = addRowNumbers(cells, o.rowNumberStart())
+	}
+	return cells, nil
 }
 
-func newColorTextRenderer(style, formatter string) *tableRenderer {
+func newColorTextRenderer(style *chroma.Style, formatter string, palette Palette, fill bool) *tableRenderer {
 	s := getStyle(style, formatter)
+	palette = palette.withDefault()
+
+	// In filled mode, each reset sequence is followed by current line's background color.
+	// Cells are rendered before lines are written, so writers restore the background by restoreBackground.
+	var bodyBackground, headerBackground, background string
+	if fill {
+		bodyBackground, headerBackground = getBackgroundEscapeSequence(style, formatter, palette.Header)
+	}
 
 	findCategory := func(t chroma.TokenType) string {
 		clr, ok := s[t]
@@ -54,59 +128,77 @@ func newColorTextRenderer(style, formatter string) *tableRenderer {
 		}
 		return text
 	}
-	// todo color
+	role := func(t chroma.TokenType, title bool) chroma.TokenType {
+		if title {
+			return palette.Header
+		}
+		return t
+	}
 	return &tableRenderer{
 		intCell: func(a int64, title bool) string {
-			t := chroma.LiteralNumber
-			if title {
-				t = chroma.KeywordNamespace
-			}
-			return wrap(t, strconv.FormatInt(a, 10))
+			return wrap(role(palette.Number, title), strconv.FormatInt(a, 10))
 		},
 		uintCell: func(a uint64, title bool) string {
-			t := chroma.LiteralNumber
-			if title {
-				t = chroma.KeywordNamespace
-			}
-			return wrap(t, strconv.FormatUint(a, 10))
+			return wrap(role(palette.Number, title), strconv.FormatUint(a, 10))
 		},
 		floatCell: func(a float64, title bool) string {
-			t := chroma.LiteralNumber
-			if title {
-				t = chroma.KeywordNamespace
-			}
-			return wrap(t, strconv.FormatFloat(a, 'f', 6, 64))
+			return wrap(role(palette.Number, title), strconv.FormatFloat(a, 'f', 6, 64))
 		},
 		stringCell: func(a string, title bool) string {
-			t := chroma.LiteralNumber
-			if title {
-				t = chroma.KeywordNamespace
-			}
-			return wrap(t, a)
+			return wrap(role(palette.String, title), a)
 		},
 		boolCell: func(a bool, title bool) string {
-			t := chroma.LiteralStringBoolean
-			if title {
-				t = chroma.KeywordNamespace
-			}
 			if a {
-				return wrap(t, "true")
+				return wrap(role(palette.Bool, title), "true")
 			} else {
-				return wrap(t, "false")
+				return wrap(role(palette.Bool, title), "false")
 			}
 		},
+		timeCell: func(a time.Time, title bool) string {
+			return wrap(role(palette.Time, title), a.Format(time.RFC3339))
+		},
+		nullCell: func(title bool) string {
+			return wrap(role(palette.Null, title), "")
+		},
 		otherCell: func(a any, title bool) string {
-			t := chroma.LiteralNumber
-			if title {
-				t = chroma.KeywordNamespace
+			return wrap(role(palette.String, title), fmt.Sprintf("%v", a))
+		},
+		styledCell: func(s string, t chroma.TokenType) string {
+			return wrap(t, s)
+		},
+		markerCell: func(s string, t chroma.TokenType) string {
+			if s == "" {
+				return ""
 			}
-			return wrap(t, fmt.Sprintf("%f", a))
+			// faint (SGR 2) on top of the token's color
+			return "\033[2m" + findCategory(t) + s + "\033[0m"
+		},
+		ruledCell: func(s string, c ruledCell) string {
+			return getEntryEscapeSequence(c.entry, formatter) + s + "\033[0m"
 		},
 		border: func(s string) string {
-			return wrap(chroma.LineTable, s)
+			if clr := findCategory(palette.Border); clr != "" {
+				return clr + s + "\033[0m" + background
+			}
+			return s
 		},
-		borderStart: findCategory(chroma.LineTable),
+		borderStart: findCategory(palette.Border),
 		borderEnd:   "\033[0m",
+		lineStart: func(header bool) string {
+			if header {
+				background = headerBackground
+			} else {
+				background = bodyBackground
+			}
+			return background
+		},
+		lineEnd: "\033[0m",
+		restoreBackground: func(s string) string {
+			if background == "" {
+				return s
+			}
+			return strings.ReplaceAll(s, "\033[0m", "\033[0m"+background)
+		},
 	}
 }
 
@@ -131,18 +223,134 @@ func newPlainTextTableRenderer() *tableRenderer {
 				return "false"
 			}
 		},
+		timeCell: func(a time.Time, title bool) string {
+			return a.Format(time.RFC3339)
+		},
+		nullCell: func(title bool) string {
+			return ""
+		},
 		otherCell: func(a any, title bool) string {
-			return fmt.Sprintf("%f", a)
+			return fmt.Sprintf("%v", a)
+		},
+		styledCell: func(s string, t chroma.TokenType) string {
+			return s
+		},
+		markerCell: func(s string, t chroma.TokenType) string {
+			return s
+		},
+		ruledCell: func(s string, c ruledCell) string {
+			return s + c.marker
 		},
 		border: func(s string) string {
 			return s
 		},
 		borderStart: "",
 		borderEnd:   "",
+		lineStart: func(header bool) string {
+			return ""
+		},
+		lineEnd: "",
+		restoreBackground: func(s string) string {
+			return s
+		},
+	}
+}
+
+// applyMarkers replaces null and missing cells of body rows with markerCell.
+func applyMarkers(table [][]any, o Opt) [][]any {
+	if len(table) == 0 {
+		return table
+	}
+	palette := o.Palette.withDefault()
+	var marker func(v any) any
+	marker = func(v any) any {
+		switch cell := v.(type) {
+		case nil:
+			return markerCell{text: o.NullMarker, token: palette.Null}
+		case missingCell:
+			return markerCell{text: o.MissingMarker, token: palette.Missing, missing: true}
+		case ruledCell:
+			cell.value = marker(cell.value)
+			return cell
+		case styledCell:
+			cell.value = marker(cell.value)
+			return cell
+		}
+		return v
+	}
+	result := make([][]any, len(table))
+	result[0] = table[0]
+	for r, row := range table[1:] {
+		newRow := make([]any, len(row))
+		for c, v := range row {
+			newRow[c] = marker(v)
+		}
+		result[r+1] = newRow
+	}
+	return result
+}
+
+// exportTable converts the table into plain values for renderers except built-in table renderers.
+// Custom renderers (e.g. CSV) can't type-switch on internal cell types, so row numbers are added as int,
+// highlighted cells (e.g. diff) are unwrapped, and missing cells become nil as same as null values.
+func exportTable(r Renderer, table [][]any, o Opt) [][]any {
+	switch r.(type) {
+	case terminalRenderer, markdownRenderer, interactiveRenderer:
+		return table
 	}
+	if o.RowNumber {
+		table = addRowNumbers(table, o.rowNumberStart())
+	}
+	result := make([][]any, len(table))
+	for i, row := range table {
+		newRow := make([]any, len(row))
+		for c, v := range row {
+			newRow[c] = unwrapCell(v)
+		}
+		result[i] = newRow
+	}
+	return result
+}
+
+// unwrapCell returns original value of wrapped cells. Null and missing cells become nil.
+func unwrapCell(v any) any {
+	switch c := v.(type) {
+	case ruledCell:
+		return unwrapCell(c.value)
+	case styledCell:
+		return unwrapCell(c.value)
+	case rowNumber:
+		return int(c)
+	case markerCell, missingCell:
+		return nil
+	}
+	return v
+}
+
+// rowNumberStart returns start value of row index. nil means 1, so 0-based index needs pointer to 0.
+func (o Opt) rowNumberStart() int {
+	if o.RowNumberStart == nil {
+		return 1
+	}
+	return *o.RowNumberStart
+}
+
+// addRowNumbers prepends "#" column to the table. Header row is not counted.
+func addRowNumbers(table [][]any, start int) [][]any {
+	result := make([][]any, len(table))
+	for r, row := range table {
+		newRow := make([]any, 0, len(row)+1)
+		if r == 0 {
+			newRow = append(newRow, "#")
+		} else {
+			newRow = append(newRow, rowNumber(start+r-1))
+		}
+		result[r] = append(newRow, row...)
+	}
+	return result
 }
 
-func calcTableSize(table [][]any, tr *tableRenderer, eastAsianAmbiguousAsWide bool) ([]int, [][]string) {
+func calcTableSize(table [][]any, tr *tableRenderer, eastAsianAmbiguousAsWide bool) ([]int, []bool, [][]string) {
 	max := func(a, b int) int {
 		if a > b {
 			return a
@@ -150,44 +358,18 @@ func calcTableSize(table [][]any, tr *tableRenderer, eastAsianAmbiguousAsWide bo
 		return b
 	}
 	var maxWidths []int
+	var rowNumberCounts []int
 	var renderCells [][]string
 	for rowIndex, row := range table {
 		renderRow := make([]string, len(row))
 		for i, c := range row {
-			switch v := c.(type) {
-			case string:
-				renderRow[i] = tr.stringCell(v, rowIndex == 0)
-			case bool:
-				renderRow[i] = tr.boolCell(v, rowIndex == 0)
-			case int:
-				renderRow[i] = tr.intCell(int64(v), rowIndex == 0)
-			case int8:
-				renderRow[i] = tr.intCell(int64(v), rowIndex == 0)
-			case int16:
-				renderRow[i] = tr.intCell(int64(v), rowIndex == 0)
-			case int32:
-				renderRow[i] = tr.intCell(int64(v), rowIndex == 0)
-			case int64:
-				renderRow[i] = tr.intCell(v, rowIndex == 0)
-			case uint:
-				renderRow[i] = tr.uintCell(uint64(v), rowIndex == 0)
-			case uint8:
-				renderRow[i] = tr.uintCell(uint64(v), rowIndex == 0)
-			case uint16:
-				renderRow[i] = tr.uintCell(uint64(v), rowIndex == 0)
-			case uint32:
-				renderRow[i] = tr.uintCell(uint64(v), rowIndex == 0)
-			case uint64:
-				renderRow[i] = tr.uintCell(v, rowIndex == 0)
-			case float64:
-				renderRow[i] = tr.floatCell(v, rowIndex == 0)
-			case float32:
-				renderRow[i] = tr.floatCell(float64(v), rowIndex == 0)
-			default:
-				renderRow[i] = tr.otherCell(v, rowIndex == 0)
-			}
 			if len(maxWidths) <= i {
 				maxWidths = append(maxWidths, 0)
+				rowNumberCounts = append(rowNumberCounts, 0)
+			}
+			renderRow[i] = formatCell(tr, c, rowIndex == 0)
+			if _, ok := c.(rowNumber); ok {
+				rowNumberCounts[i]++
 			}
 			maxWidths[i] = max(maxWidths[i], stringwidth.Calc(renderRow[i], stringwidth.Opt{
 				IsAmbiguousWide: eastAsianAmbiguousAsWide,
@@ -195,7 +377,60 @@ func calcTableSize(table [][]any, tr *tableRenderer, eastAsianAmbiguousAsWide bo
 		}
 		renderCells = append(renderCells, renderRow)
 	}
-	return maxWidths, renderCells
+	rightAligns := make([]bool, len(maxWidths))
+	for i, count := range rowNumberCounts {
+		rightAligns[i] = count > 0 && count == len(table)-1
+	}
+	return maxWidths, rightAligns, renderCells
+}
+
+func formatCell(tr *tableRenderer, c any, title bool) string {
+	switch v := c.(type) {
+	case ruledCell:
+		return tr.ruledCell(formatCell(plainTextTableRenderer, v.value, title), v)
+	case styledCell:
+		return tr.styledCell(formatCell(plainTextTableRenderer, v.value, title), v.token)
+	case markerCell:
+		return tr.markerCell(v.text, v.token)
+	case missingCell:
+		return tr.nullCell(title)
+	case rowNumber:
+		return tr.intCell(int64(v), title)
+	case nil:
+		return tr.nullCell(title)
+	case string:
+		return tr.stringCell(v, title)
+	case time.Time:
+		return tr.timeCell(v, title)
+	case bool:
+		return tr.boolCell(v, title)
+	case int:
+		return tr.intCell(int64(v), title)
+	case int8:
+		return tr.intCell(int64(v), title)
+	case int16:
+		return tr.intCell(int64(v), title)
+	case int32:
+		return tr.intCell(int64(v), title)
+	case int64:
+		return tr.intCell(v, title)
+	case uint:
+		return tr.uintCell(uint64(v), title)
+	case uint8:
+		return tr.uintCell(uint64(v), title)
+	case uint16:
+		return tr.uintCell(uint64(v), title)
+	case uint32:
+		return tr.uintCell(uint64(v), title)
+	case uint64:
+		return tr.uintCell(v, title)
+	case float64:
+		return tr.floatCell(v, title)
+	case float32:
+		return tr.floatCell(float64(v), title)
+	default:
+		return tr.otherCell(v, title)
+	}
 }
 
 func canBeTable(data any) (cells [][]any, ok bool) {
@@ -208,7 +443,7 @@ func canBeTable(data any) (cells [][]any, ok bool) {
 	}
 
 	var buf bytes.Buffer
-	err := yaml.NewEncoder(&buf).Encode(data)
+	err := encodeYAML(&buf, data, 2)
 	if err != nil {
 		return nil, false
 	}
@@ -283,7 +518,7 @@ func convertTableMapToSlice(table []map[string]any) [][]any {
 			if v, ok := row[h]; ok {
 				rowSlice[c] = v
 			} else {
-				rowSlice[c] = ""
+				rowSlice[c] = missingCell{}
 			}
 		}
 		slices[r+1] = rowSlice
@@ -52,6 +52,14 @@ func Test_canBeTable(t *testing.T) {
 			want:   [][]any{{"a", "b"}, {"1", 2}, {"3", 4}},
 			wantOk: true,
 		},
+		{
+			name: "absent keys are missing cells",
+			args: args{
+				data: []map[string]any{{"a": 1, "b": nil}, {"a": 2}},
+			},
+			want:   [][]any{{"a", "b"}, {1, nil}, {2, missingCell{}}},
+			wantOk: true,
+		},
 		{
 			name: "false if in other cases",
 			args: args{
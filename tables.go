@@ -0,0 +1,83 @@
+package formatdata
+
+import (
+	"io"
+	"strings"
+)
+
+// NamedTable is a data with title for [FormatTables].
+type NamedTable struct {
+	Title   string // Title line above the table. In Markdown, it is "###" heading
+	Caption string // Optional text below the table
+	Data    any    // Data to show. Non-tabular data is rendered by Opt.Fallback
+}
+
+// FormatTables writes several data with titles and captions in one call.
+//
+// Color detection and pager are same as [FormatDataTo].
+func FormatTables(out io.Writer, tables []NamedTable, o ...Opt) error {
+	ctx, err := detectRenderContext(out, o)
+	if err != nil {
+		return err
+	}
+	return withPager(out, ctx.Opt.pager(tables), func(w io.Writer) error {
+		return renderTables(w, tables, ctx)
+	})
+}
+
+// FormatTablesWithColor is [FormatTables]'s variation that always uses escape sequence to dump colorized output.
+func FormatTablesWithColor(out io.Writer, tables []NamedTable, o ...Opt) error {
+	ctx, err := newRenderContext(out, o, true)
+	if err != nil {
+		return err
+	}
+	return renderTables(out, tables, ctx)
+}
+
+// FormatTablesWithoutColor is [FormatTables]'s variation that always doesn't use escape sequence.
+func FormatTablesWithoutColor(out io.Writer, tables []NamedTable, o ...Opt) error {
+	ctx, _ := newRenderContext(out, o, false)
+	return renderTables(out, tables, ctx)
+}
+
+func renderTables(out io.Writer, tables []NamedTable, ctx RenderContext) error {
+	w := newTableWriter(out)
+	tr := ctx.tableRenderer()
+	palette := ctx.Opt.Palette.withDefault()
+	markdown := ctx.Opt.OutputFormat == Markdown
+	for i, t := range tables {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		if t.Title != "" {
+			writeTitle(w, tr, t.Title, 3, markdown)
+		}
+		if w.err != nil {
+			return w.err
+		}
+		if err := render(t.Data, out, ctx); err != nil {
+			return err
+		}
+		if t.Caption != "" {
+			if markdown {
+				w.WriteString("\n")
+			}
+			w.WriteString(tr.styledCell(t.Caption, palette.Caption) + "\n")
+		}
+	}
+	return w.err
+}
+
+// writeTitle writes title line. In Markdown, it is heading of the level and followed by empty line.
+func writeTitle(w *tableWriter, tr *tableRenderer, title string, level int, markdown bool) {
+	if markdown {
+		if level > 6 {
+			level = 6
+		}
+		w.WriteString(strings.Repeat("#", level) + " ")
+	}
+	w.WriteString(tr.stringCell(title, true) + "\n")
+	if markdown {
+		w.WriteString("\n")
+	}
+}
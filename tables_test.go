@@ -0,0 +1,74 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTables(t *testing.T) {
+	tables := []NamedTable{
+		{Title: "Nodes", Data: []SampleStruct{{A: "a", B: 1}}, Caption: "1 node"},
+		{Title: "Pods", Data: []SampleStruct{{A: "p", B: 2}}},
+	}
+	type args struct {
+		opt Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "terminal",
+			args: args{
+				opt: Opt{},
+			},
+			wantOut: trimIndent(`
+				Nodes
+				┌───┬───┐
+				│ a │ b │
+				╞═══╪═══╡
+				│ a │ 1 │
+				└───┴───┘
+				1 node
+
+				Pods
+				┌───┬───┐
+				│ a │ b │
+				╞═══╪═══╡
+				│ p │ 2 │
+				└───┴───┘
+				`),
+		},
+		{
+			name: "markdown",
+			args: args{
+				opt: Opt{OutputFormat: Markdown},
+			},
+			wantOut: trimIndent(`
+				### Nodes
+
+				| a | b |
+				|---|---|
+				| a | 1 |
+
+				1 node
+
+				### Pods
+
+				| a | b |
+				|---|---|
+				| p | 2 |
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			assert.NoError(t, FormatTablesWithoutColor(out, tables, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/alecthomas/chroma/v2"
-	"github.com/alecthomas/chroma/v2/styles"
 )
 
 type style struct {
@@ -18,8 +17,7 @@ var formatterColors = map[string]int{
 	"terminal256": 256,
 }
 
-func getStyle(name, formatter string) map[chroma.TokenType]string {
-	s := styles.Get(name)
+func getStyle(s *chroma.Style, formatter string) map[chroma.TokenType]string {
 	if formatter == "terminal16m" {
 		return trueColorEscapeSequence(s)
 	} else {
@@ -37,25 +35,53 @@ func trueColorEscapeSequence(style *chroma.Style) map[chroma.TokenType]string {
 	for _, ttype := range style.Types() {
 		entry := style.Get(ttype)
 		if !entry.IsZero() {
-			out := ""
-			if entry.Bold == chroma.Yes {
-				out += "\033[1m"
-			}
-			if entry.Underline == chroma.Yes {
-				out += "\033[4m"
-			}
-			if entry.Italic == chroma.Yes {
-				out += "\033[3m"
-			}
-			if entry.Colour.IsSet() {
-				out += fmt.Sprintf("\033[38;2;%d;%d;%dm", entry.Colour.Red(), entry.Colour.Green(), entry.Colour.Blue())
-			}
-			if entry.Background.IsSet() {
-				out += fmt.Sprintf("\033[48;2;%d;%d;%dm", entry.Background.Red(), entry.Background.Green(), entry.Background.Blue())
-			}
-			result[ttype] = out
+			result[ttype] = trueColorEntryToEscapeSequence(entry)
 		}
 	}
 	return result
 
 }
+
+func trueColorEntryToEscapeSequence(entry chroma.StyleEntry) string {
+	out := ""
+	if entry.Bold == chroma.Yes {
+		out += "\033[1m"
+	}
+	if entry.Underline == chroma.Yes {
+		out += "\033[4m"
+	}
+	if entry.Italic == chroma.Yes {
+		out += "\033[3m"
+	}
+	if entry.Colour.IsSet() {
+		out += fmt.Sprintf("\033[38;2;%d;%d;%dm", entry.Colour.Red(), entry.Colour.Green(), entry.Colour.Blue())
+	}
+	if entry.Background.IsSet() {
+		out += fmt.Sprintf("\033[48;2;%d;%d;%dm", entry.Background.Red(), entry.Background.Green(), entry.Background.Blue())
+	}
+	return out
+}
+
+// getEntryEscapeSequence converts single style entry (e.g. [StyleRule]'s style) into escape sequence.
+func getEntryEscapeSequence(entry chroma.StyleEntry, formatter string) string {
+	if formatter == "terminal16m" {
+		return trueColorEntryToEscapeSequence(entry)
+	}
+	colors, ok := formatterColors[formatter]
+	if !ok {
+		colors = 8
+	}
+	return entryToEscapeSequence(ttyTables[colors], entry)
+}
+
+// getBackgroundEscapeSequence returns escape sequences of style's background (and default foreground) color for filled table.
+// If the header token doesn't have its own background, slightly brighter (or darker) color is used for header.
+func getBackgroundEscapeSequence(s *chroma.Style, formatter string, header chroma.TokenType) (body, head string) {
+	bg := s.Get(chroma.Background)
+	bodyEntry := chroma.StyleEntry{Colour: bg.Colour, Background: bg.Background}
+	headerEntry := chroma.StyleEntry{Colour: bg.Colour, Background: s.Get(header).Background}
+	if headerEntry.Background == bg.Background && bg.Background.IsSet() {
+		headerEntry.Background = bg.Background.BrightenOrDarken(0.15)
+	}
+	return getEntryEscapeSequence(bodyEntry, formatter), getEntryEscapeSequence(headerEntry, formatter)
+}
@@ -2,6 +2,7 @@ package formatdata
 
 import (
 	"io"
+	"strconv"
 
 	"github.com/shibukawa/stringwidth"
 )
@@ -22,53 +23,143 @@ var tableHorizonChars = [][]rune{
 	[]rune("└┴┘─"),
 }
 
-func renderSliceAsTerminalTable(table [][]any, tr *tableRenderer, eastAsianAmbiguousAsWide bool, out io.Writer) {
-	// todo: EastAsianAmbiguous: true
-	maxWidths, renderCells := calcTableSize(table, tr, eastAsianAmbiguousAsWide)
-	repeat := func(r rune, length int) {
-		for i := 0; i < length; i++ {
-			io.WriteString(out, string(r))
+// RepeatHeaderByScreen is a value of [Opt.RepeatHeader] that repeats header every terminal height.
+const RepeatHeaderByScreen = -1
+
+// repeatHeaderByHeight returns row count between repeated headers that fits into the screen height.
+// It returns 0 (no repeat) if height is unknown.
+func repeatHeaderByHeight(height int) int {
+	if height <= 0 {
+		return 0
+	}
+	// each body row takes two lines (row and separator), and repeated header also takes two lines
+	if rows := (height - 2) / 2; rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+// renderSliceAsTerminalTable writes table with box-drawing characters.
+// Opt.Title is embedded in the top border and row count is embedded in the bottom border if Opt.ShowRowCount is true.
+// Header row is repeated by Opt.RepeatHeader.
+func renderSliceAsTerminalTable(table [][]any, tr *tableRenderer, opt Opt, out io.Writer) error {
+	eastAsianAmbiguousAsWide := opt.EastAsianAmbiguousAsWide
+	maxWidths, rightAligns, renderCells := calcTableSize(table, tr, eastAsianAmbiguousAsWide)
+	var title, rowCount string
+	if opt.Title != "" {
+		title = tr.stringCell(opt.Title, true)
+	}
+	if opt.ShowRowCount {
+		rowCount = tr.styledCell(formatRowCount(len(table)-1), opt.Palette.withDefault().Caption)
+	}
+	labelWidth := func(label string) int {
+		if label == "" {
+			return 0
+		}
+		// "─ " + label + " " + at least one "─"
+		return stringwidth.Calc(label, stringwidth.Opt{IsAmbiguousWide: eastAsianAmbiguousAsWide}) + 4
+	}
+	// widen the last column if labels don't fit into borders
+	if len(maxWidths) > 0 {
+		width := len(maxWidths) - 1
+		for _, m := range maxWidths {
+			width += m + 2
+		}
+		need := labelWidth(title)
+		if l := labelWidth(rowCount); l > need {
+			need = l
+		}
+		if need > width {
+			maxWidths[len(maxWidths)-1] += need - width
 		}
 	}
-	drawHorizontal := func(t int) {
+	w := newTableWriter(out)
+	drawHorizontal := func(t int, label string) {
 		chars := tableHorizonChars[t]
-		io.WriteString(out, tr.borderStart)
-		io.WriteString(out, string(chars[0]))
+		var line []rune
 		for i, m := range maxWidths {
 			if i != 0 {
-				io.WriteString(out, string(chars[1]))
+				line = append(line, chars[1])
+			}
+			for j := 0; j < m+2; j++ {
+				line = append(line, chars[3])
 			}
-			repeat(chars[3], m+2)
 		}
-		io.WriteString(out, string(chars[2]))
-		io.WriteString(out, tr.borderEnd)
-		out.Write([]byte{'\n'})
+		w.column = -1
+		w.WriteString(tr.lineStart(t == tableTop))
+		w.WriteString(tr.borderStart)
+		w.WriteString(string(chars[0]))
+		if label != "" {
+			w.WriteString(string(chars[3]) + " ")
+			w.WriteString(tr.borderEnd)
+			w.WriteString(tr.restoreBackground(label))
+			w.WriteString(tr.borderStart)
+			w.WriteString(" ")
+			line = line[labelWidth(label)-1:]
+		}
+		w.WriteString(string(line))
+		w.WriteString(string(chars[2]))
+		w.WriteString(tr.borderEnd)
+		w.WriteString(tr.lineEnd)
+		w.WriteString("\n")
 	}
 
-	drawHorizontal(tableTop)
-
-	for r, row := range renderCells {
-		io.WriteString(out, tr.border(string(tableVLine)))
-		for i, w := range maxWidths {
+	drawRow := func(r int) {
+		row := renderCells[r]
+		w.row = r
+		w.column = -1
+		w.WriteString(tr.lineStart(r == 0))
+		w.WriteString(tr.border(string(tableVLine)))
+		for i, width := range maxWidths {
 			var c string
 			if i < len(row) {
 				c = row[i]
 			}
-			out.Write([]byte{' '})
-			io.WriteString(out, c)
-			repeat(' ', 1+w-stringwidth.Calc(c, stringwidth.Opt{
+			w.column = i
+			padding := width - stringwidth.Calc(c, stringwidth.Opt{
 				IsAmbiguousWide: eastAsianAmbiguousAsWide,
-			}))
-			io.WriteString(out, tr.border(string(tableVLine)))
+			})
+			if rightAligns[i] {
+				w.Repeat(" ", 1+padding)
+				w.WriteString(tr.restoreBackground(c))
+				w.WriteString(" ")
+			} else {
+				w.WriteString(" ")
+				w.WriteString(tr.restoreBackground(c))
+				w.Repeat(" ", 1+padding)
+			}
+			w.WriteString(tr.border(string(tableVLine)))
 		}
-		out.Write([]byte{'\n'})
+		w.column = -1
+		w.WriteString(tr.lineEnd)
+		w.WriteString("\n")
+	}
+
+	repeatHeader := opt.RepeatHeader
+
+	drawHorizontal(tableTop, title)
+
+	for r := range renderCells {
+		if repeatHeader > 0 && r > 1 && (r-1)%repeatHeader == 0 {
+			drawRow(0)
+			drawHorizontal(tableHeader, "")
+		}
+		drawRow(r)
 		switch r {
 		case len(renderCells) - 1:
-			drawHorizontal(tableBottom)
+			drawHorizontal(tableBottom, rowCount)
 		case 0:
-			drawHorizontal(tableHeader)
+			drawHorizontal(tableHeader, "")
 		default:
-			drawHorizontal(tableMiddle)
+			drawHorizontal(tableMiddle, "")
 		}
 	}
+	return w.err
+}
+
+func formatRowCount(count int) string {
+	if count == 1 {
+		return "1 row"
+	}
+	return strconv.Itoa(count) + " rows"
 }
@@ -2,8 +2,12 @@ package formatdata
 
 import (
 	"bytes"
+	"io"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -111,8 +115,136 @@ func TestTerminalRenderer_RenderSliceAsTable(t1 *testing.T) {
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
 			var buf bytes.Buffer
-			renderSliceAsTerminalTable(tt.args.cells, newPlainTextTableRenderer(), false, &buf)
+			renderSliceAsTerminalTable(tt.args.cells, newPlainTextTableRenderer(), Opt{}, &buf)
 			assert.Equalf(t1, tt.want, buf.String(), "renderSliceAsTerminalTable(%v, ...)", tt.args.cells)
 		})
 	}
 }
+
+func TestTerminalRenderer_FillBackground(t *testing.T) {
+	var buf bytes.Buffer
+	renderSliceAsTerminalTable([][]any{{"a", "b"}, {"x", 1}}, newColorTextRenderer(styles.Get("monokai"), "terminal16m", Palette{}, true), Opt{}, &buf)
+	body, header := getBackgroundEscapeSequence(styles.Get("monokai"), "terminal16m", DefaultPalette.Header)
+	assert.Equal(t, "\033[38;2;248;248;242m\033[48;2;39;40;34m", body)
+	assert.NotEqual(t, body, header)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines {
+		if i < 2 {
+			assert.True(t, strings.HasPrefix(line, header), "line %d starts with header background", i)
+		} else {
+			assert.True(t, strings.HasPrefix(line, body), "line %d starts with body background", i)
+		}
+		assert.True(t, strings.HasSuffix(line, "\033[0m"), "line %d resets color at the end", i)
+	}
+	assert.Contains(t, buf.String(), "\033[0m"+body+" ", "background is restored after each cell")
+
+	// padding after colored cell is painted, not only the borders
+	stringColor := getStyle(styles.Get("monokai"), "terminal16m")[DefaultPalette.String]
+	assert.Contains(t, lines[3], stringColor+"x\033[0m"+body+" ", "background is restored after body cell")
+	assert.Contains(t, lines[1], "\033[0m"+header+" ", "background is restored after header cell")
+	assert.NotContains(t, lines[3], "\033[0m ", "no padding with default background")
+}
+
+func TestTerminalRenderer_Title(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Opt
+		want string
+	}{
+		{
+			name: "title and row count",
+			opt:  Opt{Title: "Users (2)", ShowRowCount: true},
+			want: trimIndent(`
+				┌─ Users (2) ─┐
+				│ name  │ age │
+				╞═══════╪═════╡
+				│ alice │ 30  │
+				├───────┼─────┤
+				│ bob   │ 4   │
+				└─ 2 rows ────┘
+				`),
+		},
+		{
+			name: "wide title widens the last column",
+			opt:  Opt{Title: "ユーザー一覧表"},
+			want: trimIndent(`
+				┌─ ユーザー一覧表 ─┐
+				│ name  │ age      │
+				╞═══════╪══════════╡
+				│ alice │ 30       │
+				├───────┼──────────┤
+				│ bob   │ 4        │
+				└───────┴──────────┘
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, renderSliceAsTerminalTable([][]any{{"name", "age"}, {"alice", 30}, {"bob", 4}}, newPlainTextTableRenderer(), tt.opt, &buf))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestTerminalRenderer_RepeatHeader(t *testing.T) {
+	var buf bytes.Buffer
+	table := [][]any{{"name", "age"}, {"alice", 30}, {"bob", 4}, {"carol", 12}}
+	assert.NoError(t, renderSliceAsTerminalTable(table, newPlainTextTableRenderer(), Opt{RepeatHeader: 2}, &buf))
+	assert.Equal(t, trimIndent(`
+		┌───────┬─────┐
+		│ name  │ age │
+		╞═══════╪═════╡
+		│ alice │ 30  │
+		├───────┼─────┤
+		│ bob   │ 4   │
+		├───────┼─────┤
+		│ name  │ age │
+		╞═══════╪═════╡
+		│ carol │ 12  │
+		└───────┴─────┘
+		`), buf.String())
+
+}
+
+func TestFormatData_RepeatHeaderByScreen(t *testing.T) {
+	// FormatData writes to stdout, so it is replaced with pipe
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	t.Setenv("LINES", "6")
+	table := []map[string]any{{"name": "alice"}, {"name": "bob"}, {"name": "carol"}}
+	err = FormatData(table, Opt{RepeatHeader: RepeatHeaderByScreen, Color: ColorNever})
+	w.Close()
+	assert.NoError(t, err)
+	out, _ := io.ReadAll(r)
+	assert.Equal(t, trimIndent(`
+		┌───────┐
+		│ name  │
+		╞═══════╡
+		│ alice │
+		├───────┤
+		│ bob   │
+		├───────┤
+		│ name  │
+		╞═══════╡
+		│ carol │
+		└───────┘
+		`), string(out), "$LINES is used because stdout is not terminal")
+
+	t.Setenv("LINES", "")
+	var buf bytes.Buffer
+	assert.NoError(t, FormatDataTo(table, &buf, Opt{RepeatHeader: RepeatHeaderByScreen}))
+	assert.Equal(t, 9, strings.Count(buf.String(), "\n"), "height is unknown")
+}
+
+func Test_repeatHeaderByHeight(t *testing.T) {
+	assert.Equal(t, 0, repeatHeaderByHeight(0))
+	assert.Equal(t, 1, repeatHeaderByHeight(3))
+	assert.Equal(t, 11, repeatHeaderByHeight(24))
+}
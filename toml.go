@@ -0,0 +1,52 @@
+package formatdata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// tomlRenderer writes data in TOML. Top level slice is written as array of tables ([[items]]).
+type tomlRenderer struct{}
+
+func (r tomlRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (tomlRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	src, err := encodeTOML(data, ctx.Opt.Indent)
+	if err != nil {
+		return err
+	}
+	return ctx.Highlight(out, src, "toml")
+}
+
+func encodeTOML(data any, indent int) (string, error) {
+	// Convert into generic values via YAML to use same key names as YAML output
+	var buf bytes.Buffer
+	if err := encodeYAML(&buf, data, 2); err != nil {
+		return "", err
+	}
+	var generic any
+	if err := yaml.Unmarshal(buf.Bytes(), &generic); err != nil {
+		return "", err
+	}
+	switch generic.(type) {
+	case map[string]any:
+	case []any:
+		generic = map[string]any{"items": generic}
+	default:
+		generic = map[string]any{"value": generic}
+	}
+	var b bytes.Buffer
+	e := toml.NewEncoder(&b)
+	e.Indent = strings.Repeat(" ", indent)
+	if err := e.Encode(generic); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,66 @@
+package formatdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOML(t *testing.T) {
+	type args struct {
+		data any
+		opt  Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "slice of records becomes array of tables",
+			args: args{
+				data: []SampleStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+			},
+			wantOut: trimIndent(`
+				[[items]]
+				  a = "x"
+				  b = 1
+
+				[[items]]
+				  a = "y"
+				  b = 2
+				`),
+		},
+		{
+			name: "map",
+			args: args{
+				data: map[string]any{"name": "x", "sub": map[string]any{"t": []int{1, 2}}},
+				opt:  Opt{Indent: 4},
+			},
+			wantOut: trimIndent(`
+				name = "x"
+
+				[sub]
+				    t = [1, 2]
+				`),
+		},
+		{
+			name: "scalar",
+			args: args{
+				data: 1,
+			},
+			wantOut: trimIndent(`
+				value = 1
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.args.opt.OutputFormat = TOML
+			assert.NoError(t, FormatDataWithoutColor(tt.args.data, out, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package formatdata
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	treeBranch     = "├── "
+	treeLastBranch = "└── "
+	treeVLine      = "│   "
+	treeSpace      = "    "
+)
+
+// treeRenderer writes nested data with guide lines like tree command:
+//
+//	. {2}
+//	├── cluster: x
+//	└── nodes [2]
+//	    ├── [0] {1}
+//	    │   └── name: a
+//	    └── [1] {1}
+//	        └── name: b
+type treeRenderer struct{}
+
+func (r treeRenderer) Render(table Table, out io.Writer, ctx RenderContext) error {
+	return r.RenderValue(table, out, ctx)
+}
+
+func (treeRenderer) RenderValue(data any, out io.Writer, ctx RenderContext) error {
+	node, err := encodeYAMLNode(data)
+	if err != nil {
+		return err
+	}
+	t := treeWriter{
+		w:        newTableWriter(out),
+		tr:       ctx.tableRenderer(),
+		palette:  ctx.Opt.Palette.withDefault(),
+		maxItems: ctx.Opt.TreeMaxItems,
+	}
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		t.w.WriteString(t.tr.border(".") + " " + t.typeLabel(node) + "\n")
+		t.children(node, "")
+	default:
+		t.w.WriteString(t.scalar(node) + "\n")
+	}
+	return t.w.err
+}
+
+// encodeYAMLNode converts data into yaml.Node. It keeps field order of structs and type of values.
+func encodeYAMLNode(data any) (node *yaml.Node, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = yamlPanicError(r)
+		}
+	}()
+	node = &yaml.Node{}
+	if err := node.Encode(data); err != nil {
+		return nil, err
+	}
+	for node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	return node, nil
+}
+
+type treeWriter struct {
+	w        *tableWriter
+	tr       *tableRenderer
+	palette  Palette
+	maxItems int
+}
+
+func (t *treeWriter) children(node *yaml.Node, prefix string) {
+	type child struct {
+		label string
+		value *yaml.Node
+	}
+	var items []child
+	var rest int
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			items = append(items, child{label: t.tr.stringCell(node.Content[i].Value, true), value: node.Content[i+1]})
+		}
+	} else {
+		for i, c := range node.Content {
+			// negative maxItems never collapses (0 is replaced with default by normalizeOpt)
+			if t.maxItems > 0 && i >= t.maxItems {
+				rest = len(node.Content) - i
+				break
+			}
+			items = append(items, child{label: t.tr.styledCell("["+strconv.Itoa(i)+"]", t.palette.Border), value: c})
+		}
+	}
+	for i, c := range items {
+		last := i == len(items)-1 && rest == 0
+		branch, indent := treeBranch, treeVLine
+		if last {
+			branch, indent = treeLastBranch, treeSpace
+		}
+		t.w.WriteString(prefix + t.tr.border(branch) + c.label)
+		switch c.value.Kind {
+		case yaml.MappingNode, yaml.SequenceNode:
+			t.w.WriteString(" " + t.typeLabel(c.value) + "\n")
+			t.children(c.value, prefix+t.tr.border(indent))
+		default:
+			t.w.WriteString(t.tr.border(":") + " " + t.scalar(c.value) + "\n")
+		}
+	}
+	if rest > 0 {
+		t.w.WriteString(prefix + t.tr.border(treeLastBranch) + t.tr.styledCell(fmt.Sprintf("… %d more", rest), t.palette.Type) + "\n")
+	}
+}
+
+// typeLabel returns "[length]" for sequences and "{length}" for mappings.
+func (t *treeWriter) typeLabel(node *yaml.Node) string {
+	if node.Kind == yaml.SequenceNode {
+		return t.tr.styledCell(fmt.Sprintf("[%d]", len(node.Content)), t.palette.Type)
+	}
+	return t.tr.styledCell(fmt.Sprintf("{%d}", len(node.Content)/2), t.palette.Type)
+}
+
+func (t *treeWriter) scalar(node *yaml.Node) string {
+	switch node.Tag {
+	case "!!int", "!!float":
+		return t.tr.styledCell(node.Value, t.palette.Number)
+	case "!!bool":
+		return t.tr.styledCell(node.Value, t.palette.Bool)
+	case "!!null":
+		return t.tr.styledCell("null", t.palette.Null)
+	case "!!timestamp":
+		return t.tr.styledCell(node.Value, t.palette.Time)
+	}
+	s := node.Value
+	if s == "" || strings.ContainsAny(s, "\n\r\t") {
+		s = strconv.Quote(s)
+	}
+	return t.tr.styledCell(s, t.palette.String)
+}
@@ -0,0 +1,99 @@
+package formatdata
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	type args struct {
+		data any
+		opt  Opt
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "nested",
+			args: args{
+				data: map[string]any{
+					"cluster": "x",
+					"nodes":   []SampleStruct{{A: "a", B: 1}, {A: "b", B: 2}},
+					"meta":    map[string]any{"ready": true, "owner": nil},
+				},
+			},
+			wantOut: trimIndent(`
+				. {3}
+				├── cluster: x
+				├── meta {2}
+				│   ├── owner: null
+				│   └── ready: true
+				└── nodes [2]
+				    ├── [0] {2}
+				    │   ├── a: a
+				    │   └── b: 1
+				    └── [1] {2}
+				        ├── a: b
+				        └── b: 2
+				`),
+		},
+		{
+			name: "collapse long array",
+			args: args{
+				data: map[string]any{"values": []int{1, 2, 3, 4, 5}},
+				opt:  Opt{TreeMaxItems: 2},
+			},
+			wantOut: trimIndent(`
+				. {1}
+				└── values [5]
+				    ├── [0]: 1
+				    ├── [1]: 2
+				    └── … 3 more
+				`),
+		},
+		{
+			name: "-1 never collapses",
+			args: args{
+				data: map[string]any{"values": make([]int, 25)},
+				opt:  Opt{TreeMaxItems: -1},
+			},
+			wantOut: ". {1}\n└── values [25]\n" + treeItems(25),
+		},
+		{
+			name: "scalar",
+			args: args{
+				data: "",
+			},
+			wantOut: trimIndent(`
+				""
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.args.opt.OutputFormat = Tree
+			assert.NoError(t, FormatDataWithoutColor(tt.args.data, out, tt.args.opt))
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+// treeItems returns lines of int array that has n zeros.
+func treeItems(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		branch := "├──"
+		if i == n-1 {
+			branch = "└──"
+		}
+		fmt.Fprintf(&b, "    %s [%d]: 0\n", branch, i)
+	}
+	return b.String()
+}